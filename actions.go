@@ -1,102 +1,295 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 )
 
-// PerformScan runs a scan of a given host using a script with
-// scriptName. Returns true if some changes in components and/or firmware/BIOS
-// versions and/or model name are detected, false othwerwise.
-func PerformScan(addrStr, scriptName string, components map[string]bool, withBIOSAndFirmware, withModel, dryRun bool, cfg *Config, cfgDir string) bool {
-	if dryRun {
-		// TODO(xor-xor): Wire up logger here.
-		fmt.Println("INFO: Running in dry-run mode, no changes will be saved in Ralph.")
-	}
+// PrepareScript loads a script with scriptName from cfgDir, and creates a
+// virtualenv for it (if it's a Python script with a manifest, and such
+// virtualenv doesn't exist yet), or refreshes the existing one when the
+// manifest has changed (see PrepareVenv). For Node.js scripts, packages from
+// package.json are installed instead (see PrepareNodeModules). It should be
+// called only once per ralph-cli run, even if many hosts are going to be
+// scanned.
+func PrepareScript(scriptName, cfgDir string) (Script, error) {
 	script, err := NewScript(scriptName, cfgDir)
 	if err != nil {
-		log.Fatalln(err)
+		return Script{}, err
 	}
-	if script.Manifest != nil && script.Manifest.Language == "python" && !VenvExists(script) {
-		venvPath, err := CreatePythonVenv(script)
-		if err != nil {
-			log.Fatalln(err)
+	switch {
+	case script.Manifest == nil:
+	case script.Manifest.Language == "python":
+		if _, err := PrepareVenv(script, false); err != nil {
+			return Script{}, err
 		}
-		if err := InstallPythonReqs(venvPath, script); err != nil {
-			log.Fatalln(err)
+	case script.Manifest.Language == "node":
+		if _, err := PrepareNodeModules(script); err != nil {
+			return Script{}, err
 		}
 	}
-	addr, err := NewAddr(addrStr)
-	if err != nil {
-		log.Fatalln(err)
+	return script, nil
+}
+
+// ScanOpts holds settings for PerformScan, which are common for all the hosts
+// being scanned.
+type ScanOpts struct {
+	Script              *Script     // script prepared by PrepareScript
+	Result              *ScanResult // ready-made scan result to be used instead of running Script
+	Components          map[string]bool
+	WithBIOSAndFirmware bool
+	WithModel           bool
+	DryRun              bool
+	Plan                *Plan             // in dry-run mode, changes are recorded here (if not nil)
+	Bulk                bool              // apply all changes detected on a host at once (see ApplyDiff)
+	Context             context.Context   // cancelled when the scan should be aborted (may be nil)
+	RecordDir           string            // when given, scans are recorded there (see ReplayScan)
+	batch               *Diff             // in bulk mode, changes for a single host are gathered here
+	ralphTransport      http.RoundTripper // used for talking to Ralph instead of the default one (see fakeRalph)
+}
+
+// componentNames lists components which can be given to --components switch
+// (apart from "all" and "none"), in the order in which they are processed.
+var componentNames = []string{"eth", "mem", "fcc", "cpu", "disk", "psu", "raid", "gpu", "nvme"}
+
+// optInComponents lists components which are not included in "all", so they
+// have to be requested explicitly (e.g. --components=all,psu). Ralph stores
+// them only when it exposes API endpoints for them (see APIEndpoints), which
+// stock Ralph doesn't do.
+var optInComponents = map[string]bool{"psu": true, "raid": true, "gpu": true, "nvme": true}
+
+// requested returns true when component c is requested with --components
+// switch, either explicitly or with "all".
+func (opts *ScanOpts) requested(c string) bool {
+	return opts.Components[c] || (opts.Components["all"] && !optInComponents[c])
+}
+
+// scriptParams returns ScriptParams (i.e. what is requested from scan scripts)
+// corresponding to opts.
+func (opts *ScanOpts) scriptParams() ScriptParams {
+	params := ScriptParams{WithModel: opts.WithModel}
+	if opts.Components["none"] {
+		return params
+	}
+	for _, c := range componentNames {
+		if opts.requested(c) {
+			params.Components = append(params.Components, c)
+		}
 	}
-	result, err := script.Run(addr, cfg)
-	if err != nil {
-		log.Fatalln(err)
+	return params
+}
+
+// PerformScan runs a scan of a given host using opts.Script, or - when
+// opts.Result is given - it skips running any script and uses opts.Result as
+// its output. Returns true if some changes in components and/or firmware/BIOS
+// versions and/or model name are detected, false othwerwise.
+// All the errors are returned as *ScanError, telling which component and which
+// stage of the scan they concern. Please note that when such error occurs at
+// the "send" stage, changes detected for components processed earlier are
+// already saved in Ralph - unless opts.Bulk is set, in which case all the
+// changes are applied at once (see ApplyDiff).
+func PerformScan(addr Addr, opts *ScanOpts, cfg *Config) (changesDetected bool, err error) {
+	httpClient := &http.Client{Transport: opts.ralphTransport}
+	script := opts.Script
+	if opts.RecordDir != "" && opts.Script != nil {
+		rec, recErr := newHostRecorder(addr, opts, cfg)
+		if recErr != nil {
+			return false, NewScanError(addr, "", StageScript, recErr)
+		}
+		defer func() {
+			// Scans aborted before running the script can't be replayed
+			// from its output, hence their errors are recorded too.
+			if err != nil {
+				rec.recordError(err)
+			}
+			if err := rec.save(); err != nil {
+				log.Printf("WARNING: Recording of the scan of %s is incomplete: %s", addr, err)
+			}
+		}()
+		httpClient.Transport = rec
+		// Script is shared between hosts, hence the copy.
+		s := *opts.Script
+		s.recorder = rec
+		script = &s
 	}
-	client, err := NewClient(cfg, addr, &http.Client{})
+
+	// Current state of the host is fetched from Ralph before running the
+	// script, since it's a part of the script's context (see ScriptContext).
+	client, err := NewClient(cfg, addr, httpClient)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(addr, "", StageLookup, err)
 	}
 	baseObj, err := addr.GetBaseObject(client)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(addr, "BaseObject", StageLookup, err)
 	}
 	dcAsset, err := baseObj.GetDataCenterAsset(client)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(addr, "DataCenterAsset", StageLookup, err)
+	}
+
+	var result *ScanResult
+	switch {
+	case opts.Result != nil:
+		result = opts.Result
+	case script != nil:
+		ctx := opts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		params := opts.scriptParams()
+		params.Ralph = &RalphState{BaseObjectID: baseObj.ID}
+		if dcAsset.SerialNumber != nil {
+			params.Ralph.SerialNumber = *dcAsset.SerialNumber
+		}
+		result, err = script.Run(ctx, addr, cfg, params)
+		if err != nil {
+			return false, NewScanError(addr, "", StageScript, err)
+		}
+	default:
+		return false, NewScanError(addr, "", StageScript, errors.New("neither script nor scan result given"))
+	}
+
+	if opts.Bulk {
+		// ScanOpts are shared between hosts, hence the copy.
+		hostOpts := *opts
+		hostOpts.batch = &Diff{}
+		opts = &hostOpts
+	}
+
+	changesDetected, err = updateComponents(addr, result, baseObj, dcAsset, client, opts)
+	if err != nil || opts.batch == nil {
+		return changesDetected, err
+	}
+	switch {
+	case opts.DryRun && opts.Plan != nil:
+		err = opts.Plan.Add(client, opts.batch)
+	default:
+		err = ApplyDiff(client, baseObj, opts.batch, opts.DryRun, false)
+	}
+	if err != nil {
+		return changesDetected, NewScanError(addr, "", StageSend, err)
 	}
+	return changesDetected, nil
+}
 
+// updateComponents is a helper function for PerformScan, which compares
+// components detected by scan with the ones stored in Ralph, and sends the
+// changes to Ralph (or gathers them in opts.batch, in bulk mode).
+func updateComponents(addr Addr, result *ScanResult, baseObj *BaseObject, dcAsset *DataCenterAsset,
+	client *Client, opts *ScanOpts) (bool, error) {
 	var changesDetected bool
 	if changed := verifySerialNumber(dcAsset, result, false); changed {
 		changesDetected = true
 	}
-	if changed := updateDataCenterAsset(withBIOSAndFirmware, withModel, result, baseObj, dcAsset, client, dryRun); changed {
+	changed, err := updateDataCenterAsset(opts.WithBIOSAndFirmware, opts.WithModel, result, baseObj, dcAsset, client, opts)
+	if err != nil {
+		return changesDetected, err
+	}
+	if changed {
 		changesDetected = true
 	}
-	if components["none"] {
-		return changesDetected
+	if opts.Components["none"] {
+		return changesDetected, nil
 	}
-	if components["eth"] || components["all"] {
-		if changed := updateEthernets(addr, result, baseObj, client, dryRun); changed {
-			changesDetected = true
+
+	for _, c := range componentNames {
+		if !opts.requested(c) {
+			continue
 		}
-	}
-	if components["mem"] || components["all"] {
-		if changed := updateMemory(result, baseObj, client, dryRun); changed {
-			changesDetected = true
+		if c == "eth" {
+			changed, err = updateEthernets(addr, result, baseObj, client, opts)
+		} else {
+			changed, err = updateComponentsOfType(componentUpdaters[c], result, baseObj, client, opts)
 		}
-	}
-	if components["fcc"] || components["all"] {
-		if changed := updateFibreChannelCards(result, baseObj, client, dryRun); changed {
-			changesDetected = true
+		if err != nil {
+			return changesDetected, err
 		}
-	}
-	if components["cpu"] || components["all"] {
-		if changed := updateProcessors(result, baseObj, client, dryRun); changed {
+		if changed {
 			changesDetected = true
 		}
 	}
-	if components["disk"] || components["all"] {
-		if changed := updateDisks(result, baseObj, client, dryRun); changed {
-			changesDetected = true
-		}
+	return changesDetected, nil
+}
+
+// sendDiff sends diff to Ralph, or - when running in dry-run mode with
+// opts.Plan given - records it in opts.Plan. In bulk mode, diff is only
+// gathered in opts.batch, to be applied later by PerformScan.
+func sendDiff(client *Client, diff *Diff, opts *ScanOpts) error {
+	if opts.batch != nil {
+		opts.batch.Create = append(opts.batch.Create, diff.Create...)
+		opts.batch.Update = append(opts.batch.Update, diff.Update...)
+		opts.batch.Delete = append(opts.batch.Delete, diff.Delete...)
+		return nil
+	}
+	if opts.DryRun && opts.Plan != nil {
+		return opts.Plan.Add(client, diff)
+	}
+	_, err := SendDiffToRalph(client, diff, opts.DryRun, false)
+	return err
+}
+
+// HostScanResult holds the outcome of a scan of a single host performed by
+// ScanHosts.
+type HostScanResult struct {
+	Addr            Addr
+	ChangesDetected bool
+	Err             error
+}
+
+// ScanHosts launches scanFn (which should be a closure over PerformScan) for
+// each of addrs, using a pool of workers goroutines, so at most workers hosts
+// are scanned at the same time. Hosts are scanned independently, i.e. an error
+// on one of them doesn't stop the others. Returned results are in the same
+// order as addrs.
+func ScanHosts(addrs []Addr, workers int, scanFn func(addr Addr) (bool, error)) []HostScanResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]HostScanResult, len(addrs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				changed, err := scanFn(addrs[i])
+				results[i] = HostScanResult{
+					Addr:            addrs[i],
+					ChangesDetected: changed,
+					Err:             err,
+				}
+			}
+		}()
 	}
-	return changesDetected
+	for i := range addrs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
 }
 
-func updateEthernets(addr Addr, result *ScanResult, baseObj *BaseObject, client *Client, dryRun bool) bool {
+func updateEthernets(addr Addr, result *ScanResult, baseObj *BaseObject, client *Client, opts *ScanOpts) (bool, error) {
+	const component = "Ethernet"
 	oldEths, err := baseObj.GetEthernets(client)
+	if err != nil {
+		return false, NewScanError(addr, component, StageLookup, err)
+	}
 	// TODO(xor-xor): ExcludeMgmt should be removed when similar functionality
 	// will be implemented in Ralph's API. Therefore, it should be considered as
 	// a temporary solution.
 	oldEths, err = ExcludeMgmt(oldEths, addr, client)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(addr, component, StageLookup, err)
 	}
 	var newEths []*Ethernet
 	for i := 0; i < len(result.Ethernets); i++ {
@@ -105,10 +298,10 @@ func updateEthernets(addr Addr, result *ScanResult, baseObj *BaseObject, client
 	}
 	diff, err := CompareEthernets(oldEths, newEths)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(addr, component, StageDiff, err)
 	}
 	if diff.IsEmpty() {
-		return false
+		return false, nil
 	}
 	// When IP address is marked as "exposed in DHCP" in Ralph, then the only
 	// way to delete Ethernet associated with its MAC address is through a so
@@ -117,39 +310,65 @@ func updateEthernets(addr Addr, result *ScanResult, baseObj *BaseObject, client
 	if len(diff.Delete) > 0 {
 		diff, err = ExcludeExposedInDHCP(diff, client, false)
 		if err != nil {
-			log.Fatalln(err)
+			return false, NewScanError(addr, component, StageLookup, err)
 		}
 	}
-	_, err = SendDiffToRalph(client, diff, dryRun, false)
+	err = sendDiff(client, diff, opts)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(addr, component, StageSend, err)
 	}
-	return true
+	return true, nil
 }
 
-func updateMemory(result *ScanResult, baseObj *BaseObject, client *Client, dryRun bool) bool {
-	oldMem, err := baseObj.GetMemory(client)
-	if err != nil {
-		log.Fatalln(err)
+// componentUpdater describes components of a given type, which are updated
+// by updateComponentsOfType.
+type componentUpdater struct {
+	Name       string                          // type of components, as in APIEndpoints (e.g. "Disk")
+	Descriptor componentDescriptor             // how they are compared (see compareComponents)
+	Detected   func(r *ScanResult) interface{} // a slice of them detected by scan (e.g. r.Disks)
+}
+
+// componentUpdaters holds componentUpdater for each component which can be
+// given to --components switch, apart from "eth" (see updateEthernets).
+var componentUpdaters = map[string]componentUpdater{
+	"mem":  {"Memory", memoryDescriptor, func(r *ScanResult) interface{} { return r.Memory }},
+	"fcc":  {"FibreChannelCard", fibreChannelCardDescriptor, func(r *ScanResult) interface{} { return r.FibreChannelCards }},
+	"cpu":  {"Processor", processorDescriptor, func(r *ScanResult) interface{} { return r.Processors }},
+	"disk": {"Disk", diskDescriptor, func(r *ScanResult) interface{} { return r.Disks }},
+	"psu":  {"PowerSupply", powerSupplyDescriptor, func(r *ScanResult) interface{} { return r.PowerSupplies }},
+	"raid": {"StorageController", storageControllerDescriptor, func(r *ScanResult) interface{} { return r.StorageControllers }},
+	"gpu":  {"GPU", gpuDescriptor, func(r *ScanResult) interface{} { return r.GPUs }},
+	"nvme": {"NVMeDrive", nvmeDriveDescriptor, func(r *ScanResult) interface{} { return r.NVMeDrives }},
+}
+
+// updateComponentsOfType compares components described by u, detected by scan,
+// with the ones stored in Ralph, and sends the changes to Ralph (see sendDiff).
+func updateComponentsOfType(u componentUpdater, result *ScanResult, baseObj *BaseObject, client *Client, opts *ScanOpts) (bool, error) {
+	detected := reflect.ValueOf(u.Detected(result))
+	old := reflect.New(reflect.SliceOf(reflect.PtrTo(detected.Type().Elem())))
+	if err := baseObj.getComponents(client, u.Name, old.Interface()); err != nil {
+		return false, NewScanError(client.scannedAddr, u.Name, StageLookup, err)
 	}
-	var newMem []*Memory
-	for i := 0; i < len(result.Memory); i++ {
-		result.Memory[i].BaseObject = *baseObj
-		newMem = append(newMem, &result.Memory[i])
+
+	var new []Component
+	for i := 0; i < detected.Len(); i++ {
+		c := detected.Index(i)
+		c.FieldByName("BaseObject").Set(reflect.ValueOf(*baseObj))
+		new = append(new, c.Addr().Interface().(Component))
 	}
 
-	diff, err := CompareMemory(oldMem, newMem)
+	diff, err := compareComponents(u.Descriptor, toComponents(old.Elem().Interface()), new)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(client.scannedAddr, u.Name, StageDiff, err)
 	}
 	if diff.IsEmpty() {
-		return false
+		return false, nil
 	}
-	_, err = SendDiffToRalph(client, diff, dryRun, false)
+	err = sendDiff(client, diff, opts)
 	if err != nil {
-		log.Fatalln(err)
+		return false, NewScanError(client.scannedAddr, u.Name, StageSend, err)
 	}
-	return true
+	return true, nil
 }
 
 // ExcludeMgmt filters eths by excluding Ethernets associated with given IP
@@ -190,10 +409,10 @@ func ExcludeExposedInDHCP(diff *Diff, c *Client, noOutput bool) (*Diff, error) {
 			}
 			if ip.Address != "" {
 				if !noOutput {
-					fmt.Printf("WARNING: Ethernet with MAC address %s cannot be deleted, "+
+					log.Printf("WARNING: Ethernet with MAC address %s cannot be deleted, "+
 						"because IP address associated with it (%s) is marked as \"exposed in DHCP\" "+
-						"in Ralph. Please use a suitable transition from Ralph's GUI for that.\n",
-						ec.MACAddress.String(), ip.Address) // TODO(xor-xor): Use logger instead.
+						"in Ralph. Please use a suitable transition from Ralph's GUI for that.",
+						ec.MACAddress.String(), ip.Address)
 				}
 				continue
 			}
@@ -220,88 +439,12 @@ func checkIfExposedInDHCP(m *MACAddress, c *Client) (IPAddress, error) {
 	return IPAddress{}, nil
 }
 
-func updateFibreChannelCards(result *ScanResult, baseObj *BaseObject, client *Client, dryRun bool) bool {
-	oldFCC, err := baseObj.GetFibreChannelCards(client)
-	if err != nil {
-		log.Fatalln(err)
-	}
-
-	var newFCC []*FibreChannelCard
-	for i := 0; i < len(result.FibreChannelCards); i++ {
-		result.FibreChannelCards[i].BaseObject = *baseObj
-		newFCC = append(newFCC, &result.FibreChannelCards[i])
-	}
-
-	diff, err := CompareFibreChannelCards(oldFCC, newFCC)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	if diff.IsEmpty() {
-		return false
-	}
-	_, err = SendDiffToRalph(client, diff, dryRun, false)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	return true
-}
-
-func updateProcessors(result *ScanResult, baseObj *BaseObject, client *Client, dryRun bool) bool {
-	oldProcs, err := baseObj.GetProcessors(client)
-	if err != nil {
-		log.Fatalln(err)
-	}
-
-	var newProcs []*Processor
-	for i := 0; i < len(result.Processors); i++ {
-		result.Processors[i].BaseObject = *baseObj
-		newProcs = append(newProcs, &result.Processors[i])
-	}
-
-	diff, err := CompareProcessors(oldProcs, newProcs)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	if diff.IsEmpty() {
-		return false
-	}
-	_, err = SendDiffToRalph(client, diff, dryRun, false)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	return true
-}
-
-func updateDisks(result *ScanResult, baseObj *BaseObject, client *Client, dryRun bool) bool {
-	oldDisks, err := baseObj.GetDisks(client)
-	if err != nil {
-		log.Fatalln(err)
-	}
-
-	var newDisks []*Disk
-	for i := 0; i < len(result.Disks); i++ {
-		result.Disks[i].BaseObject = *baseObj
-		newDisks = append(newDisks, &result.Disks[i])
-	}
-
-	diff, err := CompareDisks(oldDisks, newDisks)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	if diff.IsEmpty() {
-		return false
-	}
-	_, err = SendDiffToRalph(client, diff, dryRun, false)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	return true
-}
-
 func updateDataCenterAsset(withBIOSAndFirmware, withModel bool, result *ScanResult,
-	baseObj *BaseObject, dcAsset *DataCenterAsset, client *Client, dryRun bool) bool {
+	baseObj *BaseObject, dcAsset *DataCenterAsset, client *Client, opts *ScanOpts) (bool, error) {
+	const component = "DataCenterAsset"
 
 	var changed bool
+	old := dcAsset.Copy()
 	if withBIOSAndFirmware {
 		changed = updateBIOSAndFirmwareVersions(result, dcAsset)
 	}
@@ -327,15 +470,16 @@ func updateDataCenterAsset(withBIOSAndFirmware, withModel bool, result *ScanResu
 		var diff Diff
 		d, err := NewDiffComponent(dcAsset)
 		if err != nil {
-			log.Fatalln(err)
+			return false, NewScanError(client.scannedAddr, component, StageDiff, err)
 		}
+		d.Old = old
 		diff.Update = append(diff.Update, d)
-		_, err = SendDiffToRalph(client, &diff, dryRun, false)
+		err = sendDiff(client, &diff, opts)
 		if err != nil {
-			log.Fatalln(err)
+			return false, NewScanError(client.scannedAddr, component, StageSend, err)
 		}
 	}
-	return changed
+	return changed, nil
 }
 
 func updateBIOSAndFirmwareVersions(result *ScanResult, dcAsset *DataCenterAsset) bool {
@@ -365,12 +509,12 @@ func updateBIOSAndFirmwareVersions(result *ScanResult, dcAsset *DataCenterAsset)
 	return changed
 }
 
+// modelNameRemark matches the remark added to DataCenterAsset by updateModelName.
+var modelNameRemark = regexp.MustCompile(">>> ralph-cli: detected model name:.*<<<")
+
 func updateModelName(result *ScanResult, dcAsset *DataCenterAsset) bool {
 	const remarkTemplate = ">>> ralph-cli: detected model name: %s <<<"
-	r, err := regexp.Compile(">>> ralph-cli: detected model name:.*<<<")
-	if err != nil {
-		log.Fatalln(err)
-	}
+	r := modelNameRemark
 	newRemark := fmt.Sprintf(remarkTemplate, result.ModelName)
 	var changed bool
 	var existingRemarks string
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/juju/testing/checkers"
@@ -257,3 +261,130 @@ func TestVerifySerialNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestScanHosts(t *testing.T) {
+	addrs := []Addr{"10.20.30.1", "10.20.30.2", "10.20.30.3", "10.20.30.4", "10.20.30.5"}
+	want := []HostScanResult{
+		{Addr: "10.20.30.1", ChangesDetected: true},
+		{Addr: "10.20.30.2", Err: errors.New("scan failed")},
+		{Addr: "10.20.30.3", ChangesDetected: false},
+		{Addr: "10.20.30.4", ChangesDetected: true},
+		{Addr: "10.20.30.5", ChangesDetected: false},
+	}
+
+	var mu sync.Mutex
+	var running, maxRunning int
+	scanFn := func(addr Addr) (bool, error) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		switch addr {
+		case "10.20.30.2":
+			return false, errors.New("scan failed")
+		case "10.20.30.1", "10.20.30.4":
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+
+	for _, workers := range []int{1, 2, 10} {
+		maxRunning = 0
+		got := ScanHosts(addrs, workers, scanFn)
+		if eq, err := checkers.DeepEqual(got, want); !eq {
+			t.Errorf("workers: %d\n%s", workers, err)
+		}
+		if maxRunning > workers {
+			t.Errorf("workers: %d\nmore hosts scanned concurrently than allowed: %d", workers, maxRunning)
+		}
+	}
+}
+
+func TestUpdateMemoryErrors(t *testing.T) {
+	fixture, err := LoadFixture(ralphTestFixturesDir, "memory_components.json")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var cases = map[string]struct {
+		getCode  int
+		sendCode int
+		want     *ScanError
+	}{
+		"#0 Error while fetching components from Ralph": {
+			500,
+			201,
+			&ScanError{Addr: "10.20.30.40", Component: "Memory", Stage: StageLookup},
+		},
+		"#1 Error while sending changes to Ralph": {
+			200,
+			500,
+			&ScanError{Addr: "10.20.30.40", Component: "Memory", Stage: StageSend},
+		},
+	}
+
+	for tn, tc := range cases {
+		getCode, sendCode := tc.getCode, tc.sendCode
+		server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				w.WriteHeader(getCode)
+				fmt.Fprintln(w, fixture)
+			default:
+				w.WriteHeader(sendCode)
+			}
+		}))
+		client.scannedAddr = "10.20.30.40"
+		result := &ScanResult{
+			Memory: []Memory{{ModelName: "Samsung DDR3 DIMM", Size: 32768, Speed: 1600}},
+		}
+
+		_, err := updateComponentsOfType(componentUpdaters["mem"], result, &BaseObject{ID: 1}, client, &ScanOpts{})
+		server.Close()
+		got, ok := err.(*ScanError)
+		if !ok {
+			t.Fatalf("%s\nexpected *ScanError, got: %#v", tn, err)
+		}
+		if got.Addr != tc.want.Addr || got.Component != tc.want.Component || got.Stage != tc.want.Stage {
+			t.Errorf("%s\n got: %+v\nwant: %+v", tn, got, tc.want)
+		}
+	}
+}
+
+func TestScanOptsScriptParams(t *testing.T) {
+	var cases = map[string]struct {
+		opts *ScanOpts
+		want ScriptParams
+	}{
+		"#0 Selected components, in the order of processing": {
+			&ScanOpts{Components: map[string]bool{"disk": true, "eth": true}, WithModel: true},
+			ScriptParams{Components: []string{"eth", "disk"}, WithModel: true},
+		},
+		"#1 All components": {
+			&ScanOpts{Components: map[string]bool{"all": true}},
+			ScriptParams{Components: []string{"eth", "mem", "fcc", "cpu", "disk"}},
+		},
+		"#2 No components": {
+			&ScanOpts{Components: map[string]bool{"none": true}},
+			ScriptParams{},
+		},
+		"#3 All components with opt-in ones": {
+			&ScanOpts{Components: map[string]bool{"all": true, "psu": true, "gpu": true}},
+			ScriptParams{Components: []string{"eth", "mem", "fcc", "cpu", "disk", "psu", "gpu"}},
+		},
+	}
+	for tn, tc := range cases {
+		got := tc.opts.scriptParams()
+		if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// BulkOperation represents a single change (i.e. an equivalent of a single
+// request sent by SendDiffToRalph) within BulkRequest.
+type BulkOperation struct {
+	Method   string          `json:"method"`
+	Endpoint string          `json:"endpoint"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// BulkRequest represents the payload sent to Ralph's bulk endpoint (see
+// APIEndpoints), which allows applying all the changes detected on a given
+// BaseObject with a single request.
+type BulkRequest struct {
+	BaseObject int              `json:"base_object"`
+	Operations []*BulkOperation `json:"operations"`
+}
+
+// NewBulkRequest creates BulkRequest from all the changes held in diff (which
+// may contain many component types at once).
+func NewBulkRequest(baseObj *BaseObject, diff *Diff) *BulkRequest {
+	req := &BulkRequest{BaseObject: baseObj.ID}
+	for _, c := range diffCategories(diff) {
+		for _, d := range c.dcs {
+			op := &BulkOperation{
+				Method:   c.method,
+				Endpoint: diffEndpoint(d, c.method),
+			}
+			if c.method != "DELETE" {
+				op.Data = json.RawMessage(d.Data)
+			}
+			req.Operations = append(req.Operations, op)
+		}
+	}
+	return req
+}
+
+// diffCategory groups DiffComponents from Diff which should be sent to Ralph
+// with the same method.
+type diffCategory struct {
+	method string
+	msg    string
+	dcs    []*DiffComponent
+}
+
+// diffCategories returns DiffComponents from diff grouped by HTTP methods, in
+// the order in which they should be sent to Ralph.
+func diffCategories(diff *Diff) []diffCategory {
+	return []diffCategory{
+		{"POST", "created", diff.Create},
+		{"PATCH", "updated", diff.Update},
+		{"DELETE", "deleted", diff.Delete},
+	}
+}
+
+// bulkUnsupported returns true if statusCode received from Ralph's bulk
+// endpoint means that this endpoint is not available.
+func bulkUnsupported(statusCode int) bool {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// ApplyDiff sends all the changes from diff (gathered for a given BaseObject)
+// to Ralph with a single request on the bulk endpoint. When Ralph doesn't
+// support bulk writes, ApplyDiff falls back to sending these changes one by
+// one, and if any of them fails, the ones already made are rolled back (see
+// applyDiffSequentially). The meaning of dryRun and noOutput is the same as
+// for SendDiffToRalph.
+func ApplyDiff(client *Client, baseObj *BaseObject, diff *Diff, dryRun bool, noOutput bool) error {
+	if diff.IsEmpty() {
+		return nil
+	}
+	if dryRun {
+		_, err := SendDiffToRalph(client, diff, dryRun, noOutput)
+		return err
+	}
+	data, err := json.Marshal(NewBulkRequest(baseObj, diff))
+	if err != nil {
+		return fmt.Errorf("error marshaling bulk request: %v", err)
+	}
+	code, _, err := client.sendToRalph("POST", APIEndpoints["Bulk"], data)
+	switch {
+	case err == nil:
+		if !noOutput {
+			for _, c := range diffCategories(diff) {
+				for _, d := range c.dcs {
+					reportDiffComponent(d, c.method, c.msg, false)
+				}
+			}
+		}
+		return nil
+	case bulkUnsupported(code):
+		return applyDiffSequentially(client, diff, noOutput)
+	default:
+		return err
+	}
+}
+
+// appliedChange records a change already made in Ralph by
+// applyDiffSequentially, so it can be rolled back if needed.
+type appliedChange struct {
+	method string
+	id     int // ID of the object in Ralph (for created ones, it's the new ID)
+	dc     *DiffComponent
+}
+
+// applyDiffSequentially sends changes from diff one by one, recording each of
+// them. When some change fails, the recorded ones are rolled back in the
+// reverse order: created objects are deleted, updated ones are PATCH-ed with
+// their old data (see DiffComponent.Old), and deleted ones are created again
+// (but since Ralph assigns them new IDs, their history is not preserved).
+func applyDiffSequentially(client *Client, diff *Diff, noOutput bool) error {
+	var applied []appliedChange
+	fail := func(err error) error {
+		if rbErr := rollback(client, applied, noOutput); rbErr != nil {
+			return fmt.Errorf("%v; rollback failed: %v", err, rbErr)
+		}
+		return fmt.Errorf("%v (changes made before this error were rolled back)", err)
+	}
+	for _, c := range diffCategories(diff) {
+		for _, d := range c.dcs {
+			var data []byte
+			if c.method != "DELETE" {
+				data = d.Data
+			}
+			_, body, err := client.sendToRalph(c.method, diffEndpoint(d, c.method), data)
+			if err != nil {
+				return fail(err)
+			}
+			id := d.ID
+			if c.method == "POST" {
+				var created struct {
+					ID int `json:"id"`
+				}
+				if err := json.Unmarshal(body, &created); err != nil {
+					// The object has been created, but without its ID there's
+					// no way to delete it, so it has to be done manually.
+					return fail(fmt.Errorf("error unmarshaling response for created %s "+
+						"(it has to be deleted manually): %v", d.Component, err))
+				}
+				id = created.ID
+			}
+			applied = append(applied, appliedChange{c.method, id, d})
+			if !noOutput {
+				reportDiffComponent(d, c.method, c.msg, false)
+			}
+		}
+	}
+	return nil
+}
+
+// rollback reverts changes recorded by applyDiffSequentially. It doesn't stop
+// on the first error, so as much as possible gets reverted - all the errors
+// are returned at once.
+func rollback(client *Client, applied []appliedChange, noOutput bool) error {
+	var errMsgs []string
+	for i := len(applied) - 1; i >= 0; i-- {
+		a := applied[i]
+		var err error
+		switch a.method {
+		case "POST":
+			endpoint := fmt.Sprintf("%s/%d", APIEndpoints[a.dc.Name], a.id)
+			_, _, err = client.sendToRalph("DELETE", endpoint, nil)
+		case "PATCH":
+			if a.dc.Old == nil {
+				err = fmt.Errorf("previous state of %s is unknown", a.dc.Component)
+				break
+			}
+			var old *DiffComponent
+			if old, err = NewDiffComponent(a.dc.Old); err == nil {
+				_, _, err = client.sendToRalph("PATCH", diffEndpoint(a.dc, "PATCH"), old.Data)
+			}
+		case "DELETE":
+			var newID string
+			if newID, err = recreate(client, a.dc); err == nil && !noOutput {
+				log.Printf("WARNING: %s has been recreated in Ralph under a new ID (%s), "+
+					"so its history is not preserved.", a.dc.Component, newID)
+			}
+		}
+		if err != nil {
+			errMsgs = append(errMsgs, err.Error())
+			continue
+		}
+		if !noOutput {
+			reportDiffComponent(a.dc, "", "rolled back", false)
+		}
+	}
+	if len(errMsgs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errMsgs, "; "))
+	}
+	return nil
+}
+
+// recreate creates deleted component d in Ralph again, for rollback. Its old ID
+// is not sent, since Ralph assigns a new one (which is returned, or "unknown"
+// if it's missing in Ralph's response).
+func recreate(client *Client, d *DiffComponent) (string, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(d.Data, &obj); err != nil {
+		return "", fmt.Errorf("error unmarshaling %s: %v", d.Component, err)
+	}
+	delete(obj, "id")
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling %s: %v", d.Component, err)
+	}
+	_, body, err := client.sendToRalph("POST", APIEndpoints[d.Name], data)
+	if err != nil {
+		return "", err
+	}
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil || created.ID == 0 {
+		return "unknown", nil
+	}
+	return strconv.Itoa(created.ID), nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/juju/testing/checkers"
+)
+
+func TestNewBulkRequest(t *testing.T) {
+	diff := &Diff{
+		Create: []*DiffComponent{
+			&DiffComponent{ID: 0, Name: "Memory", Data: []byte(`{"size":16384}`)},
+		},
+		Update: []*DiffComponent{
+			&DiffComponent{ID: 2, Name: "Ethernet", Data: []byte(`{"firmware_version":"1.4"}`)},
+		},
+		Delete: []*DiffComponent{
+			&DiffComponent{ID: 3, Name: "Disk", Data: []byte(`{"size":476}`)},
+		},
+	}
+	want := `{"base_object":1,"operations":[` +
+		`{"method":"POST","endpoint":"memory","data":{"size":16384}},` +
+		`{"method":"PATCH","endpoint":"ethernets/2","data":{"firmware_version":"1.4"}},` +
+		`{"method":"DELETE","endpoint":"disks/3"}]}`
+
+	got, err := json.Marshal(NewBulkRequest(&BaseObject{1}, diff))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(got) != want {
+		t.Errorf("\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestApplyDiff(t *testing.T) {
+	newEth := &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "10 Gbps", "1.4"}
+	oldEth := &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "1 Gbps", "1.2"}
+	newMem := &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""}
+	oldMem := &Memory{3, BaseObject{1}, "Samsung DDR3 DIMM", 8192, 1600, ""}
+	var dcs []*DiffComponent
+	for _, c := range []Component{newMem, newEth, oldMem, oldEth} {
+		dc, err := NewDiffComponent(c)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		dcs = append(dcs, dc)
+	}
+	dcs[1].Old = oldEth
+	diff := &Diff{
+		Create: []*DiffComponent{dcs[0]},
+		Update: []*DiffComponent{dcs[1]},
+		Delete: []*DiffComponent{dcs[2]},
+	}
+
+	var cases = map[string]struct {
+		codes  map[string]int // "METHOD /path/" -> status code (200 if missing)
+		errMsg string
+		want   []string
+	}{
+		"#0 Bulk endpoint available": {
+			codes:  map[string]int{"POST /bulk/": 201},
+			errMsg: "",
+			want:   []string{"POST /bulk/"},
+		},
+		"#1 Bulk endpoint fails": {
+			codes:  map[string]int{"POST /bulk/": 500},
+			errMsg: "500 Internal Server Error",
+			want:   []string{"POST /bulk/"},
+		},
+		"#2 Bulk endpoint unavailable, fallback succeeds": {
+			codes:  map[string]int{"POST /bulk/": 404},
+			errMsg: "",
+			want: []string{
+				"POST /bulk/",
+				"POST /memory/",
+				fmt.Sprintf("PATCH /ethernets/2/ %s", dcs[1].Data),
+				"DELETE /memory/3/",
+			},
+		},
+		"#3 Bulk endpoint unavailable, fallback fails and gets rolled back": {
+			codes:  map[string]int{"POST /bulk/": 405, "DELETE /memory/3/": 500},
+			errMsg: "changes made before this error were rolled back",
+			want: []string{
+				"POST /bulk/",
+				"POST /memory/",
+				fmt.Sprintf("PATCH /ethernets/2/ %s", dcs[1].Data),
+				"DELETE /memory/3/",
+				fmt.Sprintf("PATCH /ethernets/2/ %s", dcs[3].Data),
+				"DELETE /memory/7/",
+			},
+		},
+		"#4 Rollback fails": {
+			codes:  map[string]int{"POST /bulk/": 404, "DELETE /memory/3/": 500, "DELETE /memory/7/": 500},
+			errMsg: "rollback failed",
+			want: []string{
+				"POST /bulk/",
+				"POST /memory/",
+				fmt.Sprintf("PATCH /ethernets/2/ %s", dcs[1].Data),
+				"DELETE /memory/3/",
+				fmt.Sprintf("PATCH /ethernets/2/ %s", dcs[3].Data),
+				"DELETE /memory/7/",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		var got []string
+		codes := tc.codes
+		server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+			if r.Method == "PATCH" {
+				body, _ := ioutil.ReadAll(r.Body)
+				req = fmt.Sprintf("%s %s", req, body)
+			}
+			got = append(got, req)
+			code, ok := codes[fmt.Sprintf("%s %s", r.Method, r.URL.Path)]
+			if !ok {
+				code = 200
+			}
+			w.WriteHeader(code)
+			if r.Method == "POST" {
+				fmt.Fprintln(w, `{"id": 7}`)
+			}
+		}))
+
+		err := ApplyDiff(client, &BaseObject{1}, diff, false, true)
+		server.Close()
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+		}
+		if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
+
+func TestApplyDiffInvalidResponse(t *testing.T) {
+	eth := &Ethernet{0, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "10 Gbps", "1.4"}
+	mem := &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""}
+	var dcs []*DiffComponent
+	for _, c := range []Component{eth, mem} {
+		dc, err := NewDiffComponent(c)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		dcs = append(dcs, dc)
+	}
+	diff := &Diff{Create: dcs}
+	want := []string{
+		"POST /bulk/",
+		"POST /ethernets/",
+		"POST /memory/",
+		"DELETE /ethernets/7/",
+	}
+
+	var got []string
+	server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		switch r.URL.Path {
+		case "/bulk/":
+			w.WriteHeader(404)
+		case "/ethernets/":
+			w.WriteHeader(201)
+			fmt.Fprintln(w, `{"id": 7}`)
+		case "/memory/":
+			w.WriteHeader(201)
+			fmt.Fprintln(w, "Created")
+		default:
+			w.WriteHeader(204)
+		}
+	}))
+	defer server.Close()
+
+	err := ApplyDiff(client, &BaseObject{1}, diff, false, true)
+	errMsg := "changes made before this error were rolled back"
+	if err == nil || !strings.Contains(err.Error(), errMsg) {
+		t.Errorf("didn't get expected string: %q in err msg: %q", errMsg, err)
+	}
+	if eq, err := checkers.DeepEqual(got, want); !eq {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestApplyDiffRollbackDelete(t *testing.T) {
+	var dcs []*DiffComponent
+	for _, c := range []Component{
+		&Memory{3, BaseObject{1}, "Samsung DDR3 DIMM", 8192, 1600, ""},
+		&Memory{4, BaseObject{1}, "Samsung DDR3 DIMM", 8192, 1600, ""},
+	} {
+		dc, err := NewDiffComponent(c)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		dcs = append(dcs, dc)
+	}
+	diff := &Diff{Delete: dcs}
+	// Deleted Memory is created again without its old ID.
+	want := []string{
+		"POST /bulk/",
+		"DELETE /memory/3/",
+		"DELETE /memory/4/",
+		`POST /memory/ {"base_object":1,"model_name":"Samsung DDR3 DIMM","size":8192,"slot":"","speed":1600}`,
+	}
+
+	var got []string
+	server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+		switch req {
+		case "POST /bulk/":
+			w.WriteHeader(404)
+		case "DELETE /memory/4/":
+			w.WriteHeader(500)
+		case "POST /memory/":
+			body, _ := ioutil.ReadAll(r.Body)
+			req = fmt.Sprintf("%s %s", req, body)
+			w.WriteHeader(201)
+			fmt.Fprintln(w, `{"id": 8}`)
+		default:
+			w.WriteHeader(204)
+		}
+		got = append(got, req)
+	}))
+	defer server.Close()
+
+	err := ApplyDiff(client, &BaseObject{1}, diff, false, true)
+	errMsg := "changes made before this error were rolled back"
+	if err == nil || !strings.Contains(err.Error(), errMsg) {
+		t.Errorf("didn't get expected string: %q in err msg: %q", errMsg, err)
+	}
+	if eq, err := checkers.DeepEqual(got, want); !eq {
+		t.Errorf("%s", err)
+	}
+}
@@ -4,6 +4,7 @@
 // bundled_scripts/idrac.toml
 // bundled_scripts/ilo.py
 // bundled_scripts/ilo.toml
+// bundled_scripts/scan_result.v1.schema.json
 // DO NOT EDIT!
 
 package main
@@ -71,7 +72,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _idracPy = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x3b\x7b\x53\xe2\xc8\xb7\xff\xf3\x29\xfa\x66\x6a\x2b\x50\xab\x01\x7c\xcc\xce\x70\x87\xd9\x42\x88\x0e\xb5\x82\x16\xe0\xee\x6c\x79\xad\x54\x08\x8d\x66\xcd\x83\x4d\x07\x91\x6b\xf9\xdd\x7f\xe7\x74\xe7\xd1\x79\xa9\x38\xbf\x61\xaa\x4c\xd2\x7d\x5e\x7d\x5e\x7d\x4e\x27\xf3\xe1\x7f\x9a\x6b\x16\x34\xe7\xb6\xd7\xa4\xde\x03\x59\x6d\xc3\x3b\xdf\xab\xd5\x6c\x77\xe5\x07\x21\xf9\x87\xc1\x43\x74\xef\xb3\xf8\x8e\x6d\x93\xdb\xf5\xda\x5e\xd4\x96\x81\xef\x12\xcb\x5f\x6d\x49\x34\xba\xa0\x74\x85\xcf\x62\xe6\xd1\x75\x34\x1a\x06\x94\xc6\xd3\xba\x43\x5d\xea\x85\x33\x1c\x32\x19\xd1\x67\x09\xbf\x80\xfe\xbb\xa6\x2c\x64\x02\x31\x7e\xd2\x56\xa6\x75\x6f\xde\x52\xa6\xad\x03\xc7\xb1\xe7\x87\x1a\x7d\xb4\xe8\x2a\xb4\x7d\x8f\xc5\x34\x87\x1e\xa3\xd6\x3a\xa0\x13\x81\xf3\x97\x19\x78\xb6\x77\x5b\xab\x4d\xfb\xdf\xf4\x51\x8f\x74\x89\x72\x17\x86\xab\x4e\xb3\xc9\xac\x3b\xea\x9a\x4c\x5b\xb8\xe1\x52\xf3\x83\xdb\xe6\x66\x4e\xdd\xe6\x86\x59\xb6\xdb\x6c\x37\xe1\xef\xbe\x80\x68\x1e\x28\xb5\xef\xa3\xf3\xf1\xd4\x98\x22\xf6\x53\x84\xbe\xd9\x6c\xb4\xcd\x21\x47\x3c\x68\xb5\x0e\x9b\xad\xe3\x26\xf3\xcd\xd5\x3e\x28\x8f\x3a\xfe\x8a\x3e\xc7\x58\x7f\x4d\xf5\xb1\x8c\x18\xf3\x05\x6d\x20\x82\x60\xcd\x90\xc8\x51\xb3\xf5\x19\x94\xbf\x76\x69\x60\xe2\x9a\x24\x12\xa3\x5e\x29\x8d\xbc\xec\xae\xe9\x81\xec\xfc\xaa\x3d\xb2\x45\x42\x60\xdc\x36\x4e\x7a\x53\xbd\x94\x04\x75\x1c\xcd\xf2\xdd\xea\xe5\x37\x7f\x61\x40\xe8\x03\x39\xa3\x1e\x0d\x6c\x8b\x70\xf2\xfb\x56\x60\x2e\x43\xba\x20\xb8\x06\xe2\x52\xc6\xc0\x2e\xb5\xe9\x45\xef\xd2\xd0\xc7\x57\x23\x21\xb2\x31\xd3\x47\x97\xe7\xbd\x19\x32\x56\x55\xf5\xcb\xef\xb0\x66\xf2\x40\x03\x06\x8b\xeb\x2a\x6d\xad\xa5\xfc\xfe\xb5\xf6\x85\x75\xf4\x48\x67\xe8\x21\x1e\xeb\xb0\xae\xf2\x46\x25\x2b\x11\xc6\x86\x99\xdd\xbc\x5d\x4b\xf5\xfb\xa9\x69\x2e\x16\x01\x48\x0b\x3e\x91\x22\xc3\x7a\x0a\xe8\xaf\xa8\x36\x45\xa6\x45\xdc\xd7\x4d\xab\x7c\xad\x11\x02\x2b\xff\x46\xcd\x05\x0d\xf0\x01\x1e\x61\x15\x9d\x9e\x85\xd3\x84\x75\xdc\x35\x0b\xaf\x3c\x98\x64\xa1\xe9\x2d\xba\x4a\x18\xac\xa9\xf2\x75\x67\x46\x4d\x3d\xba\xa7\x5f\x9a\x29\x7d\x89\xe1\xcc\xaf\x64\xf6\x04\x6b\x05\xab\x62\x88\x1a\x10\x70\xcf\x82\xc2\xcc\x4f\xb0\x61\xbe\x33\xa1\xcc\x5f\x07\x16\xbd\x9a\x0c\xab\x09\x05\x11\x10\x27\x91\xc3\x92\x64\x19\x09\x37\x1a\x0e\x2a\x29\x61\x9a\xe9\x3c\xe1\xdf\x48\x9a\x04\x45\x22\x33\xa1\x2b\x67\x1b\x8b\x19\xeb\x55\x98\xfd\x4d\x0a\x94\x9d\xa4\x19\xf8\x0e\x6d\x9a\x9e\xef\x6d\x5d\x7f\xcd\x22\x25\x46\xc4\x04\xcb\x66\x81\x67\xa4\x9a\x29\x75\xa8\x15\xfa\xc1\x94\x86\x92\x2c\xf2\x0c\x19\x9b\x2e\xed\x2a\x86\x01\xf1\xe6\xc1\x2d\x83\x04\x87\xfa\x62\xd1\x7c\xa2\xaf\x18\x21\x65\x59\xc2\xe0\x4b\x53\x76\x28\xf0\xae\x13\x7f\xb1\x4d\x24\xa2\x5e\x27\xf1\x85\x9c\x38\x17\x90\x42\x5d\xfb\xff\xa9\x2e\xf9\x4d\x0e\x64\x64\x3e\x46\xd9\x9a\xa1\x5f\x3c\x1a\x34\x7a\x4a\x44\x94\x21\x12\x29\xf3\x4c\x51\x44\x21\x15\xde\xc5\x71\xff\xb5\x06\xd9\xa1\x56\x1b\xf5\xfa\xc6\xe5\x44\x3f\x1d\x7e\x37\x4e\xce\x7b\xfd\x3f\xce\x87\xd3\x19\x64\x8e\x6b\x4e\x4d\x3d\x6e\xc1\xbf\x23\x75\x8f\xa8\x87\x87\xc7\xad\x8f\xa7\x78\xd7\x6a\x7d\xfe\xf4\xdb\x47\x71\x87\xbf\xe4\xae\x8f\x77\x07\xad\xa3\xf6\xf1\x21\xde\xb5\x8f\x3e\xb7\x0f\x60\x56\x50\x6a\x81\x99\x05\xa5\x53\xfd\x14\x7e\x1c\xa2\x77\xfa\xf9\x40\xe0\x1f\xb4\x3e\x89\xbb\x81\xde\x1b\x1c\xf4\x05\x5c\x6f\x70\x34\x00\xfc\x9b\xda\x54\x9f\x0c\x7b\xe7\x25\x02\x8e\x7d\x8f\x02\x28\x82\x8f\xfd\x90\xf4\x1e\x4c\xdb\x31\xe7\x0e\xc5\x81\xef\x8f\xfc\x1f\xde\xee\xe3\x0f\x6f\xae\xaf\xbc\x7b\xcf\xdf\x78\x37\xa9\xf8\x62\x09\x42\x48\xa4\x31\x5d\x51\xcb\x5e\xda\x74\x81\x20\x7f\xff\xd1\x6e\xf5\x07\x5c\xd6\x83\xc3\xa3\xe3\x8f\xbf\x7d\xfa\xdc\x12\xcc\x3c\xce\x03\x02\xf9\x84\x92\x53\xdb\x71\x20\x2b\x9f\x6c\xc9\x85\xa6\x6b\x23\x8d\xcb\x5c\x1b\xe8\x7f\x0e\xfb\xba\x31\x1c\x9f\x5e\xc8\x39\xf9\x89\xb3\x52\x5c\x1f\x36\x01\x03\xfd\x4f\xe9\x10\x45\x11\x02\x28\x34\xbc\xa3\x81\x47\x43\x06\x83\xd7\x37\xd1\xa0\x4b\x5d\x3f\xd8\xca\x23\x4b\x7b\x1e\x50\xc3\xba\x33\x3d\x0f\x88\x58\x66\xb0\xc8\x20\xac\x02\xdf\x82\x50\xf1\x83\xcc\xe8\xc2\x66\xf7\x99\x01\x06\x3b\x8b\x09\x32\xac\xdd\x39\x0d\x64\x29\x96\x76\xe0\x6e\x4c\xe0\x10\x6d\x1b\xf2\xdc\xdc\xf6\x59\x6e\xfc\xb9\xa6\xcf\xbe\xe9\x93\xb1\x3e\x2b\x5b\xa7\x69\xc9\xe8\xe5\xcb\x66\x2b\x4a\x17\x38\xb0\x16\xe6\x21\x62\xe0\x65\x71\x9e\x6b\x97\x93\x8b\xbe\x3e\x9d\x5e\x4c\xde\xac\xdf\x98\x11\xf7\x1b\x31\x64\xf9\x90\x56\x92\xa1\xe7\xda\x48\x1f\x5d\x4c\xfe\x7e\x3b\x45\x08\xe1\x2c\xc1\x2c\x8f\xe7\xda\xe9\xf0\x64\xa2\x1b\xfd\x6f\xbd\xf1\x58\x3f\x37\xfa\xbd\xc9\xa0\x48\xfc\x03\xc9\x2f\x72\x4f\xe8\x80\x40\x1e\x26\x1b\xd0\x08\x4c\x91\xb5\xb7\x66\x30\x54\xb7\x07\x93\x5e\x9f\x2c\x7c\xca\x3c\x35\x24\x60\xed\x07\x7b\x41\x49\x78\x67\x43\x41\xe6\x2d\x7d\xb2\xa5\x61\x43\x7b\xdd\x94\xef\xb1\x05\x88\x92\xa8\x7f\x30\x9c\xfe\xf1\x23\x7a\xaa\x72\x3f\xe6\xf8\x61\x16\xb4\x7c\x15\xa8\xb6\xdd\x54\xf2\x5c\xab\xd5\x16\x74\x49\x3c\x3f\x70\x4d\x07\x24\x32\xc0\x3d\x8d\x68\xe3\xa9\x4b\xf7\x8d\x0e\x67\x2c\x8d\xc0\xfa\xa4\x27\x6d\xbd\x5a\xd1\xa0\xde\xd0\x02\xd8\x82\x60\xfb\xa8\xab\x3c\xbd\x74\xd4\x06\xc7\x0b\x68\xb8\x0e\x3c\x19\x01\x18\x57\x17\xd4\x10\x99\x98\xb4\x8c\x8d\xa8\x9b\x59\xbd\xbc\x9e\x6e\x00\x11\xcb\x31\x41\x96\xe1\x22\x30\x2d\x3d\x08\xfc\xa0\xae\xc7\xc5\x78\x24\xf2\xca\xe4\xcc\x22\x38\xd4\x4a\xdd\x9f\xff\x03\x3b\x16\xcc\x73\x00\x5c\xbf\x61\xd8\x9e\x1d\x1a\x46\x1d\xf6\xbc\xe5\x1e\xb9\xf3\x59\xb8\x47\x40\x91\xc1\x1e\xc7\xdf\xf8\xc1\x22\x22\x87\x3f\x04\xd2\x10\x06\x74\x80\x97\xec\x04\xa2\xc1\x04\x5e\xb2\x13\x31\x25\x98\x8c\x6f\x53\x09\x82\xb5\x67\x40\x01\x0c\x7b\xd8\x22\x12\x82\x4b\xcc\xdd\x66\x8f\xc4\x5b\x71\x57\x0d\x7c\x3f\x6c\x2e\x60\xa7\x56\x25\x81\xb2\x25\x52\xdc\x5e\x30\x28\x31\x9e\x9e\xc5\xc6\xa8\x68\x4b\x34\x71\x58\x4f\x64\x6f\x24\xd8\xb7\x58\x52\xc3\xd6\xb8\x30\xb0\xa4\x41\xd1\xe1\xa2\xe1\x9f\x76\x3d\x85\x8a\xaa\x6b\x98\xae\xaa\xaf\x63\x16\x09\x8a\xb0\xbc\xa8\xb1\xba\xa2\xf5\xd1\xa0\x92\x0a\xec\x55\x5d\x6d\xaa\x0d\xf2\x2b\x81\x0b\xfc\x95\x56\x9a\xc1\xcd\x2e\xab\x9b\x7d\xcc\x82\xa2\xb4\xdd\xec\x42\xb2\x00\x89\x06\xe3\x9b\x3c\xab\xb4\x98\xe8\x1e\x1c\x1f\xa7\xb3\xa9\x06\x22\x27\xd6\x67\x1a\xf4\x33\x42\x91\x06\xd4\x16\x0b\x03\x8b\xb7\xec\xaa\x51\x5c\x35\x6f\x03\xb5\x68\x83\x9c\x10\x42\xc5\xdd\xe8\x2a\xc9\xd0\x90\x5c\x35\x65\x29\xfc\x04\x75\x11\xa3\x4a\x3e\xa1\x28\xca\x2c\xd8\x92\x10\x2a\x6b\x40\xc8\x74\x48\x38\xb8\x02\xf6\xdc\x59\xd6\x58\x60\x12\x94\x95\xcc\x4d\x66\x5b\x09\x01\x73\x0d\x7b\xaf\x17\xda\x16\x2f\xc5\x34\x48\x3f\x21\x78\x62\x78\x67\x86\x64\x43\x21\xb3\x60\x5e\x61\xa0\x48\xe8\x9a\xbd\x2d\x30\x61\x98\x88\x78\x72\xc1\x45\x62\xc6\x4e\x48\x41\x7a\x21\x0f\x90\x5f\x16\x60\x1c\x32\x9d\x9e\x13\x8b\x06\x21\xd4\x14\x40\x9a\x6a\xa4\x07\xe8\x4b\xdf\x71\xfc\x0d\x4a\x12\x67\x05\xb2\x81\x22\x02\x9e\xf6\x51\xfc\x84\x12\x17\x91\x8b\x46\xee\x78\x91\x49\xcc\x5b\xd3\xf6\x34\x79\xd9\xa9\xc1\xc0\x59\xd3\x24\x03\x0b\x2e\x18\x29\xab\x7f\x10\xcf\x2c\x2a\x3f\xd6\x45\xb7\x9e\x84\xf7\x5e\x36\xa0\x73\x66\x84\x9c\x6c\x2f\xb7\xdd\x53\xd3\x61\x39\x32\x42\x64\xd6\x7d\xca\x8c\xe2\x4f\xed\xfb\x5e\x08\xda\xde\x9f\x6d\x57\x54\xed\x10\xd5\x5c\xad\x9c\x48\xf5\xbc\xdb\xfc\x15\x9a\x84\xff\x85\x02\x27\x60\x34\xec\x5e\xcd\x4e\xf7\x3f\xa9\x59\xda\xcf\x65\x2e\x6b\x63\x6a\x0f\x49\xa0\xf9\xf7\x9d\x0c\x34\x4c\x04\x1a\x34\x35\xe1\x9a\x41\xda\x81\x9d\xa1\xdb\x25\x47\xad\x76\xa7\x20\x58\x60\xda\x8c\xca\xf9\x55\xe9\xa1\xf2\x29\xde\x6b\x64\xe8\x71\xb3\xf2\x64\x87\xf1\x4b\xc0\xd0\xb1\x56\x34\xa5\x51\x7b\x91\x52\x81\x97\x32\x85\x85\xf2\xb9\x0e\x81\x56\xc6\x87\xa4\xdf\x21\x4f\xcf\xff\xe7\x45\x69\x1f\x1f\x94\xd2\x2c\x93\xf0\xd0\x42\xfa\x18\x26\xe1\xb0\x57\x00\xca\x99\x2a\x95\x90\x2f\x88\x19\x2b\x13\x16\xd7\x25\xea\x13\x7b\xc6\x0e\xa1\x09\xd7\x53\x73\xed\x84\x69\xec\x76\xa3\x63\x98\x02\xaa\x63\xf3\x0d\xe1\xfa\x26\x3f\xe1\x71\xfd\xc6\x79\x43\x88\xd8\xd0\x96\x36\x64\x7a\x89\x6b\xc6\x68\x12\x66\xd6\x24\x39\x5e\x08\x60\x20\x3d\x08\xa0\x80\xa4\x4f\xb6\x27\x93\xd0\xec\x10\xe2\x0d\xc6\xeb\x8d\x9b\x1d\x4d\xa2\x66\x4c\x92\x58\x61\x0f\x1e\x18\x37\x50\xc4\x07\x07\xd5\x17\x4d\x13\x9b\x84\xa8\x7b\xaa\xf6\x8f\x6f\x7b\x75\x69\x2d\x8d\x12\x4b\x55\x18\x2a\xca\xc2\x42\x8d\x51\xf9\x62\xdc\xd2\xd0\x80\xd4\x40\x0d\x4c\x3f\x75\x1b\x17\x64\x88\xed\x22\x88\x52\xe2\x82\x3e\xd8\x96\x98\x07\xc5\x95\xb5\x22\x1c\x8c\x1f\x0c\x76\x49\x86\x82\x26\xef\xcd\xea\xa0\x3f\x1c\x19\xd3\x2d\x0b\xa9\xfb\xa7\x4d\x37\x51\x81\xc3\xcf\x61\x0c\xaf\x0d\xb8\xd9\xc3\xae\x5f\x88\x92\xc3\x10\xf9\xe9\x5f\x7e\x0c\x16\x39\xd9\x73\xd2\x9e\xc6\x6a\x85\xb1\x21\xc0\x33\xb8\xe6\xd1\x0b\x5a\x8e\x1c\x72\x2f\x37\x80\x47\x7e\xc5\x31\xd8\xb0\xd3\xc1\x58\x6a\x31\x12\x97\x6a\xd0\x00\x2c\xb0\xbc\x43\x5d\x70\x37\x35\x1d\xa7\xfe\xaf\x98\x8d\x13\x8a\x00\x4a\x7d\xb3\x98\x26\x86\x1e\x80\x00\x5c\x08\xdb\x03\xdb\x40\xa2\x06\x9f\x84\x0d\x25\x67\xab\x38\x49\xa4\x35\xb2\xd0\x4b\x69\xa4\x47\x5c\xaf\x5b\x37\x22\x7a\x32\x1e\x02\x48\x12\x4e\xb2\x32\xa2\x8e\x4c\x6f\xbd\x34\x2d\xf0\x1a\x1a\xa8\x92\xa3\x35\xb8\x07\x69\xa2\x1e\x49\x0b\x57\x05\x92\x9a\xa5\x41\x31\xad\x28\x12\xf0\x3b\x59\xe3\xaa\xaa\x79\xca\x6a\xcf\x14\xff\x7c\xdf\xaa\x60\x58\xc9\xac\x7f\x07\x89\xd7\x66\x53\x1a\xa0\xa7\xcf\xcc\xdb\x98\x71\x96\x69\x6c\xc6\x2c\x43\x34\x2a\x18\x28\x7f\xae\x90\x1a\x58\x8a\x9f\x6b\x35\x83\xab\xde\x80\xb8\x99\x91\x7c\xc0\x5d\xab\xf9\x7e\x85\xe3\xbc\x4f\xa7\xe7\xf6\x92\x5a\x5b\xcb\xa1\xb8\x63\x06\x50\x36\xd0\xe0\xcf\x88\x68\x66\xbd\x45\x21\xe4\x4e\xfd\x07\x04\x38\x19\x5e\x4c\x23\x8e\x53\x50\xa9\x77\xfb\x1a\xdf\xd4\xb7\x39\xd7\xf4\x51\x6e\x8d\x24\x0c\x39\xa9\x25\xe7\x1f\x85\xa4\x96\x14\x84\x08\x06\x8d\x55\x5d\xaa\xfd\x3e\x10\xaa\xdd\x6a\xa4\xbf\x86\x00\xf4\xc2\x51\xaf\x1f\x9d\x15\x76\xc8\xc9\x49\xa7\xdf\xef\x0c\x06\x1d\x5d\xef\x9c\x9e\x76\x5a\xad\x04\x27\x0c\xb6\x9d\x5c\x49\x6c\x25\x3a\x2a\xd1\xcf\xcb\xbe\x98\xe7\xac\xe6\x37\xde\x54\x57\xf8\x13\x6f\x50\x48\x2f\x04\x85\xce\xd7\x21\x15\x3b\x4e\x89\x38\xd8\x03\xcb\x1b\x25\x8c\x96\x81\x55\xf6\xb3\x8d\x7c\x15\x04\x63\xd7\x9d\x8f\x37\xe8\xfc\x65\x27\x7f\xc5\x82\xa8\x44\x8e\xb4\xbb\xcd\x59\x05\x2d\xcd\xb9\x16\x4c\x73\x19\xf8\x8b\xb5\x15\xe2\xc9\x6b\x07\x12\x4e\x08\x70\x93\x06\xd1\x23\x6b\x93\x76\xeb\x8c\x1c\x5d\x92\xef\xc7\x07\xad\xe6\xf0\xf0\xb8\x45\x82\xf1\xa0\x4f\xf6\x77\x32\x1f\x32\x7f\xb7\x01\x25\xf9\xfe\x0b\xa6\x8b\x44\x29\x31\x1e\xea\x9d\x4f\xe7\x30\xdc\x6c\x25\x85\x3f\xac\x70\xdc\x04\x5e\x63\x50\x1f\x87\xb2\xd3\x4b\x84\x1d\xea\xd5\xdd\x06\xd6\xb4\x6d\xac\x48\x63\x3e\x45\x58\xfc\x59\x90\x47\x6c\x6f\x4d\x8b\x96\x76\x35\xa8\xc2\xa1\xfb\x00\x5a\xa5\xeb\x51\x49\x54\xc9\xb8\x6e\xa1\x3e\xe1\x30\x59\x77\xe0\xe7\x46\x75\x3e\x91\x71\x88\x2b\x6c\x99\xc2\xb5\x07\xdb\xbf\xb3\xdd\x23\x36\xb4\x55\x14\xb6\x7e\xd1\x6a\xa1\x47\x50\x95\x81\x47\xc3\xe8\xca\xc4\x1a\x81\x2c\x6d\xea\x2c\xb8\x3e\xf0\x3c\x07\x9a\x11\x5f\xa2\x06\xbd\xd9\x9d\xf9\xc0\x7b\xbc\xd0\xbc\xa7\x48\x8e\xbf\xbe\x94\x2c\xda\x14\x1a\xcc\x77\x09\x25\x76\x10\x47\x6e\xdd\xfc\xd9\x57\x6a\x7c\x07\x70\x95\x33\xfb\xd6\x9c\xdb\xa1\x52\x61\xcc\x84\x48\x9b\x9c\xcd\x57\x4c\xc6\x66\xb4\x04\x94\x25\x47\x67\xf2\x4f\x6d\xb7\x46\xd0\x18\x01\x95\x16\x19\x21\x99\x62\xc9\x08\x20\x1c\x06\x40\x5e\x80\x39\x43\x2a\x89\x30\xa5\x54\xce\x62\x46\x55\x20\x47\x11\xc8\x51\x35\x08\xc8\x70\x16\xcb\x52\x02\xf3\x5c\xf0\x6d\x3c\x98\x8b\x14\xa0\xdd\xd3\x2d\xab\xf0\x6d\x56\xa1\xe5\xf8\x17\x6b\x5b\x50\xba\x66\x37\xa5\x50\xf3\x80\x9a\xf7\xd9\xce\xa2\x60\x0c\x99\x5a\x95\x03\x44\xde\xce\x47\xb3\xde\xbe\xdc\xc4\x7b\x6d\xc9\xce\x74\x6a\xba\xb6\xb3\x8d\x76\xd1\x0e\x69\x1f\x6b\x2d\xed\xe0\xb7\xea\x64\xf6\x20\xd5\x44\xbb\xa6\xb2\x0c\xaf\x1f\x4f\x66\x42\x94\xb2\xfc\x0f\x33\xb5\x5d\x9a\x88\xf1\xb0\xbf\x4b\x07\x11\x81\xbf\xab\x7d\x88\x71\x7f\x72\xef\x90\x54\x2b\x69\xf6\x46\xcf\x16\x76\xe3\x0d\x40\xb6\xa5\x48\x35\x1b\x63\x02\x62\xfc\xb9\x46\xbd\xf0\x12\x45\x3a\x8c\xe4\x9b\x70\x52\xf9\x14\x32\x59\xbe\x2a\x28\xe4\xf8\x98\x1f\x54\x67\xa6\x25\xca\x32\x33\x3d\xfa\xca\x74\x23\xd9\x9d\xbc\x8c\xc2\x8b\xf5\x5d\x16\x96\x07\x0a\x07\xcb\xed\x08\x1c\xa1\x8c\x7a\x69\xe9\x9c\x0f\xb0\x02\x1e\x8b\xb7\xae\x78\x20\x73\x0e\x9f\x40\xc9\xa5\x66\xfa\x92\xac\xb4\x81\x7e\xa3\x53\xf7\x2f\xaf\x76\x71\xea\x08\xfc\x5d\x4e\x1d\xe3\xfe\x64\xa7\x4e\xf5\xb2\xb3\x57\x27\xa8\xb2\x5b\x17\x5f\xd2\x35\x8a\x08\x05\xaf\xaa\xcc\x7d\xaf\x35\x9f\x15\xbd\x67\x19\xcb\xd4\x39\x6d\x2f\xac\xef\xce\x31\xaa\xfa\xfb\x8e\x6f\xdd\x4f\x39\xad\xca\xd6\xb7\x94\x3f\x7f\xf3\xf8\x03\xfc\xc7\xbc\xfb\xbc\x58\x5e\xc6\x24\xfb\x9c\xe0\x2e\x42\x24\x71\x93\x8c\x64\x02\x27\x85\x93\x23\x47\xbc\x8f\xfe\x91\xa8\x19\x71\x0a\xbb\x04\x4e\x8a\xf1\xae\xd8\x91\xd0\x7f\x62\xf8\xf0\x8b\xd0\xce\xce\xb1\x03\x68\x72\xd4\xe4\xde\x42\xcb\xef\xa5\xdc\x42\xb0\xa8\xfc\x00\xa9\xfc\x3c\x52\x76\xab\x37\x9e\x19\x95\x9d\xda\xec\x46\x4e\x84\x62\x15\x9d\xdc\x62\xf0\xcd\x70\x12\x05\xbb\x33\x9b\x22\x7a\xa3\x22\xda\xf3\xac\x32\x11\xff\x0e\x5e\x22\xca\xdf\xc2\x0c\x7c\x20\x69\xa7\xa8\x9b\x7d\x2d\xcc\x67\xe5\x88\xe2\x9f\x66\x54\x1f\x79\xa4\xed\x3d\x02\x1a\xa8\xb0\x3a\xfe\x31\x6c\xcf\x98\x6f\x43\xca\xe4\x97\xb5\x30\x1e\xad\x2f\x03\xc2\x3f\x24\xc8\x8c\xc4\xf2\x63\xcb\xd8\x6a\x54\x11\x20\x4d\xe8\xce\x0f\x8e\xb2\x97\x42\xfb\x87\x90\x3b\x15\x83\x97\x77\x5b\x66\x5b\xa6\x33\x80\x05\xed\x92\x07\xf2\x78\xef\xca\x06\x05\x22\x3f\x79\x4b\xe5\xe6\xdd\x39\x23\x20\x96\x9c\x12\x32\x9f\x5b\xc8\xb5\x61\x1a\xbb\xef\xdb\x38\xdf\x74\x60\x9c\x11\x6b\xa7\x0c\x24\x0b\xf8\x33\x93\x49\xd6\xdd\x5f\xd0\x84\x8a\xac\xd4\x8a\x54\x32\xf4\x4e\x10\xbf\xa8\x88\x9c\x02\x92\xac\xf5\x7a\x70\xa6\x12\x96\x1e\x7a\xef\x24\x21\xa7\x20\x36\xfe\xd7\x45\x7c\xf9\xd8\x5a\x64\x04\x79\x44\xce\x08\xaa\x9a\x8a\xed\xf8\xe1\xfb\xa4\x05\xc4\xd7\xa5\x44\xa0\x38\x25\xe3\x43\x83\xb7\xfd\xc8\x13\x7b\xf4\x6c\xeb\xc9\x23\x29\xce\xab\xf8\x90\x49\xac\x7c\x56\xce\xab\x25\xdf\xc9\xfd\x48\xd9\x72\xd9\x1f\x0e\xf8\xb9\xf5\x4e\x19\x4b\x46\x7a\x5f\xba\xca\x50\xf8\xc9\xb9\x6a\x69\x09\x3d\xed\x5e\xc0\xc8\x4d\xe4\xce\x59\x48\x19\x50\x66\x81\xf3\xf1\xef\xb4\xab\x5d\x06\x3c\x43\xe5\x46\x25\x91\x51\xd5\xf8\x55\x4e\xca\x5e\x73\xfc\x0d\x7e\x9b\xf5\x4a\x4b\x1c\x2d\x54\xce\xb0\x2f\x7c\xa9\xd7\xc8\xe3\xbd\xa5\x0f\x8e\x75\x19\x3b\x6c\xf4\x9c\xf1\xd9\x18\x26\x72\x5b\xe1\x82\xd2\xdb\x91\x37\xbc\xdd\x7d\xf1\x75\x70\x1e\xfe\x5a\x4d\xba\x61\x2e\xf6\x8b\xaf\x5d\x8a\xc8\x69\x47\x90\x62\x57\x76\xd2\x45\x74\x51\xfa\xa4\xa8\xa5\xad\x44\x11\x8d\x07\x76\x8a\x55\x56\x2e\x15\x91\x4a\x82\x3f\x25\xf1\x7a\x66\xc8\x24\x96\xc2\xdb\x2a\x66\x99\x5e\xfd\x85\xef\xe6\xc0\x51\xc5\x17\x73\x10\xe9\xca\x4b\xef\x8d\xc7\x3e\x19\x5e\x92\xf8\x23\x43\xfc\x7c\x09\x28\x93\x3b\x93\x91\x39\xa5\x5e\xfc\x25\x63\xf2\x75\x09\xd0\x15\x1f\xdc\xbd\x81\x6e\xfa\x01\x59\xfa\xc1\xca\x4b\x84\xd3\x0f\xf6\x76\x23\x9e\xe0\x55\x13\x97\x35\x0b\x26\x10\xdf\x25\x96\xaa\xaf\xc4\xbf\x5f\x8b\x89\xe8\xd8\x02\xf7\x0f\xfc\x2f\x53\xda\x62\xed\xae\x58\x5d\x82\xc7\xef\xc9\x6a\x36\x7e\xf6\x88\x3a\x30\x0c\x5c\xa0\x6a\x00\x3e\x6c\xd1\x86\x2a\x16\x1a\x7d\xdf\xe8\x33\x8d\x7a\x0f\x76\x00\x64\xc0\x4d\xea\xea\xf0\xd2\x98\x5d\x18\xd3\x7e\x6f\xac\xf2\x57\xe4\x1c\x36\xfa\xe4\x31\x0f\x0b\xb9\xb5\x77\xa6\x8f\xf4\xf1\xcc\xb8\x9a\xea\x13\x63\xdc\x1b\xe9\x12\x96\xf4\x3d\xe4\x6b\x98\x97\xbd\xe9\xf4\xaf\x8b\xc9\x40\xc2\xce\x9c\x11\x57\x7b\x1f\x07\x89\x8e\x75\x53\x8b\xe1\x7f\xf4\xa2\xf2\x39\x0d\xea\x8a\x6a\x66\x70\x8b\x6f\x83\xa5\x02\x65\x0b\x82\x3d\xda\x61\xbd\xdd\xa8\xfd\x27\x00\x00\xff\xff\x6b\x33\xfc\x14\x9c\x36\x00\x00")
+var _idracPy = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x3c\x7f\x73\xe2\x38\xb2\xff\xf3\x29\xf4\x3c\x75\x65\x78\xc7\x18\xc8\x24\x7b\xbb\xbc\x61\xaf\x18\x42\x66\xa9\x0b\x24\x0f\x98\xd9\xb9\xca\x4b\xb9\x14\x5b\x80\x76\x6c\xd9\x2b\x89\x10\x36\x95\xef\xfe\xaa\xe5\x5f\x92\x31\x09\x24\x37\x43\x6a\xb0\xe5\xfe\xa5\xee\x56\xab\xd5\x96\x78\xf7\x5f\xad\xb5\xe0\xad\x3b\xca\x5a\x84\xdd\xa3\x78\x2b\x57\x11\xab\xd5\x68\x18\x47\x5c\xa2\x3f\x44\xc4\xb2\xeb\x48\x64\x57\x62\x9b\x5f\xae\xd7\xd4\xaf\x2d\x78\x14\x22\x2f\x8a\xb7\x28\x6d\xf5\x09\x89\xe1\x3e\x79\xf2\x10\x06\x0e\x91\x9c\x90\xec\xf1\x30\x20\x21\x61\x72\x0e\x4d\x58\xa0\xe1\x3c\xe7\xc7\xc9\x9f\x6b\x22\xa4\x48\x10\xb3\x3b\x27\xc6\xde\x77\xbc\x24\xc2\x59\xf3\x20\xa0\x77\x1f\x1c\xf2\xe0\x91\x58\xd2\x88\x89\x8c\xe6\x88\x09\xe2\xad\x39\x99\x26\x38\xbf\x63\xce\x28\x5b\xd6\x6a\xb3\xc1\x6f\xc3\x71\x1f\xf5\x90\xb5\x92\x32\xee\xb6\x5a\xc2\x5b\x91\x10\x0b\xc7\x0f\xe5\xc2\x89\xf8\xb2\xb5\xb9\x23\x61\x6b\x23\x3c\x1a\xb6\x3a\x2d\x8f\x86\xef\x13\x88\xd6\x89\x55\xfb\x36\xbe\x9c\xcc\xdc\x19\x60\x3f\xa6\xe8\x9b\xcd\xc6\xd9\x7c\x50\x88\x27\xed\xf6\x87\x56\xfb\xac\x25\x22\x1c\xbf\x27\xec\x9e\x04\x51\x4c\x9e\x32\xac\xdf\x67\xc3\x89\x8e\x98\xf1\x7d\x08\x03\x40\x50\x14\x36\xa2\x75\xd2\x6e\x9f\xb6\xda\xbf\xb4\x08\x5b\x87\x84\x63\xe8\x93\x46\x62\xdc\xaf\xa4\x51\x96\x3d\xc4\xac\xd5\x49\xbe\x9d\x07\xe1\xe7\x04\x26\x1d\xf7\x53\x7f\x36\xac\x24\x41\x82\xc0\xf1\xa2\x70\x7f\xf7\x5b\x7f\x13\x4f\x56\xed\x1d\xfa\x4c\x18\xe1\xd4\x43\x8a\xfc\x7b\x8f\xe3\x85\x24\x3e\x82\x3e\xa0\x90\x08\x81\x97\xa4\x36\xbb\xea\x5f\xbb\xc3\xc9\x97\x71\x22\xb2\x3b\x1f\x8e\xaf\x2f\xfb\x73\x60\x6c\xdb\xf6\xc7\x7f\x3e\x84\x01\xba\x27\x5c\xd0\x88\xf5\xac\x8e\xd3\xb6\xfe\xf9\x6b\xed\xa3\xe8\x0e\x53\x9d\xa1\x87\x30\x60\xa2\x2b\x7a\xd6\x81\x4a\xb6\x52\x8c\x8d\xc0\x3d\xab\xd4\xb1\x4a\xfd\xfe\xdc\xc2\xbe\xcf\x89\x10\x94\x2d\x0b\xe4\x10\xb3\x9e\x75\x9c\x6a\x0b\x64\xc2\x0e\x63\x6d\x98\xd6\xfa\xb5\x86\xd0\x47\xd1\xfd\x8d\x60\x9f\x70\xb8\x41\xe8\xe3\x46\xe0\x6e\xdf\x03\xcb\x23\xd1\x0d\xd7\x42\x7e\x61\x3e\xe1\x42\x62\xe6\xf7\x2c\xc9\xd7\xc4\xfa\xf5\x68\x46\xad\x61\x7a\x4d\x3e\xb6\x0a\xfa\x1a\xc3\x79\xb4\x97\xd9\x63\x88\x19\x5e\xaa\x21\xea\xae\x79\xf0\x94\x50\x98\x47\x39\x76\x88\x59\x77\x4a\x44\xb4\xe6\x1e\xf9\x32\x1d\xed\x27\xc4\x53\x20\x45\xa2\x84\xa5\xc9\x32\x4e\xdc\x68\x74\xbe\x97\x12\x84\x99\xee\x23\xfc\x9f\x4a\x93\xa3\x68\x64\xa6\x24\x0e\xb6\x99\x98\x69\x5b\x3f\x31\xfb\xaf\xc7\x3a\x49\x8b\x47\x01\x69\x61\x16\xb1\x6d\x18\xad\xc5\xc7\x96\x4e\x2c\x61\xd9\xda\xe1\x99\xaa\x66\x46\x02\xe2\xc9\x88\xcf\x88\xd4\x64\xd1\x9f\xa0\x09\x0e\x49\xcf\x72\x5d\x8f\x86\x0c\x87\x44\xc4\xd8\x03\x7d\x89\xf4\x79\xae\xaf\x0c\xa1\x60\x59\xc1\xe0\x63\x4b\x77\xa8\x8f\xa2\xfb\x29\xf2\xb7\xb9\x44\x84\x75\x73\x5f\x28\x89\x73\x15\x4b\x1a\xd2\xbf\x48\xf6\x1c\xfc\xa6\x04\x32\xc6\x0f\x69\xb4\x16\xbf\x3e\x86\xf8\xc1\x25\xe9\x5d\x2e\xa2\x0e\x91\x4b\x59\x66\x0a\x22\x26\x52\x7d\x6c\x15\xe3\xfe\xd7\x9a\x6d\xdb\xb5\xda\x3b\xf4\x35\x89\x0d\x28\x5a\x20\xb9\x22\x48\x78\x9c\xc6\x52\x20\x2f\x62\x92\x63\x4f\xa2\xba\x20\x04\x71\x1c\xc4\xab\xf7\x5e\x40\x91\x1f\x79\xa2\x81\xe4\x8a\x8a\x14\x14\x20\x17\x11\x0f\x05\x92\x91\x53\x1b\x5c\x4d\xe6\xd3\xfe\x60\xee\x7e\x1d\x4e\x67\xa3\x2b\x88\xa0\x9d\x5a\xff\xf2\xd2\x1d\x5c\x8d\xaf\xaf\x26\xc3\xc9\x1c\x22\xfa\x8d\x12\xd6\x26\x72\x65\x37\x91\x1d\x92\x10\xbe\x16\x9e\x07\x5f\x5e\xbc\x86\x2f\x9f\x8a\xef\xf0\x1d\x0b\x75\xcb\x31\xf5\xe1\x7b\x99\x3c\x65\xf7\x21\xb1\x9b\xb5\xdb\x5a\x6d\xdc\x1f\xb8\xd7\xd3\xe1\xc5\xe8\x9b\xfb\xe9\xb2\x3f\xf8\xd7\xe5\x68\x36\x2f\x38\x9c\xb5\xcf\xda\x67\xa7\x80\xf1\xe1\xc3\x59\xfb\xa7\x0b\xb8\x6a\xb7\x7f\xf9\xf9\x1f\x3f\x25\x57\xf0\xc9\xaf\x06\x70\x75\xd2\x3e\xed\x9c\x7d\x80\xab\xce\xe9\x2f\x9d\x93\xb6\xdd\x4c\x28\xb5\x4f\xda\x29\xa5\x8b\xe1\xc5\xc5\x85\xa2\xd4\xe9\x5f\xfc\x02\x10\xea\xe9\xcf\xc9\xd5\xf9\xb0\x7f\x7e\xa2\x28\x5d\x0c\xfb\xe7\xa7\xe7\x4a\xca\xd9\x70\x3a\xea\x5f\x56\x08\x38\x89\x18\x69\x22\x1b\xc0\x27\x91\x44\xfd\x7b\x4c\x03\x7c\x17\x10\x68\xf8\xf6\xa0\x3e\x70\xf9\x1e\xfe\xc1\xc5\xcd\x17\xf6\x9d\x45\x1b\x76\x5b\x88\x0f\x9f\x4c\x48\xa0\x31\x8b\x89\x47\x17\x94\x28\x75\xfd\xfb\x5f\x9d\xf6\xe0\x1c\xae\x3a\x27\x1f\x4e\xcf\x7e\xfa\xc7\xcf\xbf\x28\x29\x81\x2f\x7c\xcf\x23\xf4\x89\xa0\x0b\x1a\x04\xc4\x47\x9f\xb6\xe8\xca\x19\x3a\x63\x47\xc9\x5c\x3b\x1f\x7e\x1d\x0d\x86\xee\x68\x72\x71\xa5\x4f\x2a\x8f\x8a\x95\x15\x46\x3e\x09\x5c\x18\x40\x56\x17\x59\x56\x22\x80\x45\xe4\x8a\x70\x46\xa4\xb0\xba\xe8\xe6\x36\x6d\x0c\x49\x18\xf1\xad\xde\xb2\xa0\x77\x9c\xb8\xde\x0a\x33\x46\x02\xd7\xc3\xdc\x37\x10\x62\x1e\x79\x44\x88\x88\x1b\xad\xe0\x11\x26\x58\xb4\x21\xdc\x15\xeb\x38\x0e\x28\x31\x9e\x08\x19\x71\xbc\x24\xae\x72\xe2\x28\x08\x88\x49\x69\x19\xaf\x8d\x7b\xf0\x26\xd7\xe7\xf4\xbe\x44\x85\x70\x8a\x03\x97\xad\xc3\x3b\xc2\xf5\x5e\x2e\x28\x0f\x37\x98\x13\x37\x9d\x57\xf5\x67\x77\x34\x12\xa5\xf6\xa7\xda\x70\xfe\xdb\x70\x3a\x19\xce\xab\xf4\x88\x3d\x1d\xbd\x5a\xad\x22\x26\xc4\x87\x86\x75\x62\x7e\x94\x34\x3c\x2f\xce\x53\xed\x7a\x7a\x35\x18\xce\x66\x57\xd3\x83\xed\x97\x31\x02\xff\x48\x9b\xbc\x88\x13\x61\x36\x89\xc8\xfb\x4e\x64\xce\x66\x3c\x1c\x5f\x4d\xff\x7d\x38\x0f\xfa\x17\x29\xd1\xdb\xe5\x2a\x82\xa8\x60\x70\x31\xfa\x34\x1d\xba\x83\xdf\xfa\x93\xc9\xf0\xd2\x1d\xf4\xa7\xe7\xbb\xcc\xde\xa1\xb2\x1a\x9a\x89\x96\x10\x66\x3e\xda\x6c\x18\xc2\x9c\xa0\x35\x5b\x0b\xe2\xa3\x3a\x3d\x9f\xf6\x07\xc8\x8f\x88\x60\xb6\x44\x31\x8f\xee\xa9\x4f\x92\xa8\x46\xd9\x22\x42\x5b\x22\x1b\xce\xcb\xc6\x7e\x8d\xb5\x36\x9b\xc2\x40\xe7\xa3\xd9\xbf\xde\xa2\xb7\x7d\x0e\x9a\x6a\x4f\x03\xad\xee\x05\xa8\xed\x38\x95\x3c\xd5\xae\xaf\x7e\x1f\x4e\xdd\xd9\x97\xeb\xeb\xcb\xc3\x6d\xbe\xc1\x52\xe2\xe5\xa1\xe2\xef\x51\xf9\x53\x6d\x36\xbf\x9a\xf6\x3f\x0f\x5d\x35\xcb\x5c\x5d\x5e\x0e\x0f\xf7\xec\x3d\x34\x95\x20\x1e\xf6\x56\xc4\x35\xf4\xfb\x54\xfb\x7c\xfd\x65\x97\xf8\x3b\x64\x08\xad\x7c\xab\x4c\xf9\x65\x47\x4b\x49\x55\xba\x5b\xb5\xf4\xc7\xab\x6a\xf2\x75\x3c\x74\xcf\xa7\xa3\xaf\xc3\x1f\xe1\x60\x7b\xd9\xd6\x6a\x3e\x59\x20\x16\xf1\x10\x07\xf4\x2f\xe2\x86\xd8\x73\xd3\xcc\xae\xae\x5d\x37\xba\x8a\x8e\xd6\x82\x7a\xfa\x9d\xb3\x8e\x63\xc2\xeb\x0d\x87\x93\x38\xc0\x1e\xa9\xdb\xef\x61\xba\xea\xda\x0d\x85\xc7\x89\x5c\x73\xa6\x23\xd4\x6a\xb5\xfd\x2b\x56\x9f\x0a\x98\x54\xdd\x4d\xb2\x30\x15\xf5\xea\x05\x6b\xa3\x56\xab\x79\x01\x16\x02\x8d\x7c\x8e\xbd\x21\xe7\x11\xaf\x0f\xb3\xd5\x6e\x2a\x72\x8c\x85\x28\xe0\xc0\xba\xf5\xe8\xee\x0f\xe2\xc9\x46\xb7\xa6\x00\xa0\xff\xae\x4b\x19\x95\xae\x5b\x17\x24\x58\x34\xd1\x2a\x12\xb2\x89\xd6\x82\xf0\xa6\xc2\xdf\x44\xdc\x4f\xc9\xc1\x1f\x00\x39\x00\x83\x7a\x0a\xd4\x7c\x00\x68\xa8\xa7\xb0\xcd\x07\x19\x25\xd4\xcb\x89\x16\x12\xf0\x35\x73\xbd\x28\x0c\x31\xf3\x53\x21\x54\xcf\x94\xcd\x9b\x28\xcb\x75\x7b\x36\x8f\x22\xd9\xf2\x3d\x1a\xda\x9a\x40\xe6\x1a\x24\x5b\xbf\x8b\x6e\xab\xf5\xf8\x94\x64\x9e\x96\x03\x29\x1f\x96\xf5\x5c\xf6\x46\x8e\xbd\x84\x35\x2b\x96\xc4\x77\x61\xcd\x00\xa2\xaf\xa9\xef\xc0\x75\xa7\x5e\x40\xa5\xcb\x57\xd4\x43\xfb\x16\xb0\x19\x8b\x1c\x05\xfe\xb2\x55\x4d\x2f\xa9\x2d\x38\x5c\x48\x4e\xe3\xba\xdd\xb2\x1b\xe8\xef\xc8\x6e\xd9\xe8\xef\x7a\x4f\x0d\x5c\xb3\x5b\x3d\xf3\xd6\x04\x05\x69\x7b\x66\x47\x4c\x80\x5c\x83\xd9\x85\xf9\x58\xcf\xd6\x7b\x27\x67\x67\xc5\xd3\x42\x03\xa9\x13\x0f\xe7\xce\xb7\xf1\x65\xa2\x48\x57\x10\xe6\xbb\xb0\xbc\x34\x7b\xbd\xe6\x41\xcf\x2e\xdb\xc0\xde\xb5\x41\x49\x88\x44\xc5\xbd\x54\xd5\x9a\x0c\x0d\xcd\x55\x0b\x96\x40\xa7\x89\xd6\x3c\x68\x66\xa8\x9a\x4f\x58\x96\x35\xe7\x5b\x24\x23\x04\x08\x46\x09\x02\x1a\xe3\x48\x28\x35\xa2\x35\x2c\xf3\x11\xc8\x8a\xee\xb0\xa0\x5e\x4e\x00\xaf\xe5\x8a\x30\x49\x3d\xb5\xd6\x71\xd0\x24\x92\xa4\x89\xe4\x0a\x4b\xb4\x21\xc8\x8f\x60\xd6\x81\xbc\x8d\x20\xcc\xb6\x48\x40\xbd\x20\x62\x2a\x3c\x82\xa3\xd1\x88\x15\xf2\xb3\x88\xa3\x7b\x1c\x50\x1f\x4b\x82\x66\xb3\x4b\xe4\x11\x2e\xe9\x02\x48\x13\x07\xf5\xd9\x16\x2d\xa2\x20\x88\x36\x20\x49\x16\x15\xd0\x86\x06\x01\xe2\xe4\x3d\x88\x9f\x53\x52\x22\x2a\xd1\xd0\x4a\x95\x05\x10\x5e\x62\xca\x9c\x1c\xc0\xb2\xac\xfc\x9a\xa3\x5e\x4e\xce\x81\x0e\xef\x18\xa9\x10\x11\x3e\x3e\x96\x78\x57\xf9\x99\x2e\x7a\xf5\x7c\x78\x37\xcd\x01\x5d\x32\xe3\x3d\xe1\x74\xb1\xed\x5d\xe0\x40\x94\xc8\x24\x22\x8b\xde\xa3\xd1\x0a\x1f\x7b\x10\x31\x49\x98\x7c\x3f\xdf\xc6\xc4\xee\x22\x1b\x43\x92\x9c\xa8\x5e\x95\x73\xfe\xfe\x10\x06\xff\xe3\xad\x30\x17\x44\xf6\xbe\xcc\x2f\xde\xff\x9c\xae\x21\xb2\xcf\x53\x95\xcb\x52\x08\xed\x12\x71\x27\xfa\x5e\x78\x46\xfa\x80\x3b\x42\x62\xb9\x16\xae\x17\xf9\x04\xf5\x7a\xe8\xb4\xdd\x31\x81\xe0\xc3\x31\x15\x44\x8f\xaf\x56\x1f\x94\x4f\x20\xd6\x3a\x68\xc4\x94\x59\x55\xb0\x83\xf1\x8b\x22\x9e\xc7\x36\xc7\x6a\xd4\x9e\xa5\xb4\xc3\xcb\x9a\x45\x38\x56\x5c\xba\x68\x4a\xe2\x88\x09\xd2\x45\x8f\x4f\xff\xc7\xd2\xb0\x0f\x37\x79\x20\xdb\xc1\x86\x3f\xee\x48\xf2\x20\xf3\xe1\x60\x6a\x08\x3e\x25\x53\x15\x12\xaa\x0e\x09\x37\xc6\x72\x05\x85\xb8\x47\xf1\x04\x4b\xf0\xd6\xa3\x78\xba\xc0\xeb\x40\x16\x63\xb7\x97\xd6\x39\x77\x50\x03\xaa\x26\x84\x9b\xdb\xf2\x03\xa6\xf4\x9b\xc5\x8d\x44\xc4\x86\xb3\xa0\xcc\xaf\xa7\x10\xc0\xb5\xa0\x47\x17\x3a\xa6\x69\x92\x12\x2f\x00\x70\x81\x1e\x5a\x44\x1c\x15\x77\x94\xe9\x24\x1c\x2a\x09\x07\xa8\x7a\xe3\xf6\x48\x93\xd8\x86\x49\x72\x2b\x34\xd1\x94\x08\x65\xa0\x94\x0f\x98\x26\xd7\xd1\x0e\x15\x2d\xb8\x35\x91\xdd\xb4\x9d\x3f\x22\xca\xb2\xce\x83\xde\x4a\x66\x31\x4d\x53\x19\x85\x13\x35\xa6\xe9\x8b\xbb\x24\xd2\xbd\xc3\x82\xb8\x10\x7e\xea\x14\xbc\xd5\x4d\xa6\x0b\x9e\x86\x44\x9f\xdc\x53\x2f\x79\x8e\x7a\xa8\x6a\xa9\xac\xc0\x54\xe5\xbd\x87\x0c\x0a\x8e\x3e\x37\xdb\xe7\x83\xd1\xd8\x9d\x6d\x85\x24\xe1\x57\x4a\x36\x69\x82\xa3\x0a\x9d\x2e\xeb\xa0\x1e\x32\xab\xc9\x7f\x43\x56\x09\x23\x89\x4f\x7f\xaa\x3a\x73\xea\x64\x4f\x79\xfd\x27\x53\x6b\xeb\xf1\x69\x24\x49\x28\x5a\x8f\x4f\x65\xf4\x1d\x2d\xa7\x0e\x59\xa8\xb0\xa8\xa9\xef\xb6\x8d\xfb\x5a\x63\x26\x75\xd2\x92\xa5\x6a\x5e\xc4\x7d\x81\x7a\x4a\x17\xca\x4d\x71\x10\xd4\xff\x6c\xd4\xf4\x80\x92\x00\x75\x6b\x7b\x3d\xc9\x1a\x31\x2f\xe2\x9c\x78\x12\x61\x26\x36\x84\x23\xca\x54\xbd\xca\xb4\x55\x16\x24\x8a\x04\x37\xd1\x4b\xe5\x48\x4f\xb9\xde\xb4\x6f\x95\x58\xc5\x03\xf8\x58\x8f\x4f\x1a\x4e\xde\x33\x64\x8f\x31\x5b\x2f\xb0\x27\xd7\x9c\x70\x5b\x73\xb4\x86\xf2\x20\x27\xc9\x47\x8a\xc4\xd5\x42\x23\xe6\x39\x56\x13\x59\x56\xa3\xf9\x56\xd6\xd0\xab\xfd\x3c\x75\xb5\x1b\x99\x3b\xea\xed\x67\xb8\x97\xd9\x60\x85\x85\xa0\x62\x46\x38\x78\xfa\x1c\x2f\x33\xc6\x26\xd3\xcc\x8c\x26\x43\x98\x25\x28\x43\xe5\xba\x57\x61\x60\x6d\xfc\xdc\xd8\x06\xae\x7d\x8b\x7a\x26\xb5\xf2\x80\xbb\xb1\xcb\xcb\x0f\xfb\xf6\xb9\x2e\x3e\xdb\xcd\x4b\xba\x20\xde\xd6\x0b\xc8\x20\xaf\x15\xa5\x15\x51\xb3\xbf\xbb\x42\xe8\x95\x9e\x37\x08\xf0\x69\x74\x35\x4b\x39\xce\x24\xa7\x6c\xf9\x12\xdf\xc2\xb7\x15\xd7\xe2\x56\x5f\x1a\x69\x18\x7a\x50\xcb\xeb\x73\x3b\x41\x2d\xe5\xb3\x40\x00\x16\x62\xaf\xae\xe5\x7e\xef\x10\x71\x96\x0e\x1a\xac\x39\x27\x4c\x8e\xfb\x83\xb4\x18\xdf\x45\x9f\x3e\x75\x07\x83\xee\xf9\x79\x77\x38\xec\x5e\x5c\x74\xdb\xed\x1c\x47\xf2\x6d\x41\x20\x5d\xeb\xe5\x3a\xaa\xd0\xcf\xb3\x3a\xda\xe1\x9c\xe9\x28\xfb\xa7\xe9\x0a\xfe\x92\x57\x94\xa8\x2f\x25\xa7\x77\x6b\x49\x92\x19\xc7\xc0\x48\xc4\x81\xb5\x6e\xde\x4c\x17\xb0\xa2\xac\x02\xdb\xbb\x9e\x35\x67\x95\x84\xc0\x4d\xf7\xa7\x5b\x88\x4e\x55\x95\x69\x93\xf8\x1e\x39\x52\x13\x86\xd8\x2b\xd2\x74\x65\x15\xb0\x34\xac\xa2\x77\x4d\x73\xcd\x23\x7f\xed\x49\x78\xb5\xd1\x45\x23\x26\x49\x50\x9f\x36\xd0\x30\xb5\x36\xea\xb4\x3f\xa3\xd3\x6b\xf4\xed\xec\xa4\xdd\x1a\x7d\x38\x6b\x23\x3e\x39\x1f\xa0\xf7\x47\x99\x0f\x98\xbf\xda\x80\x9a\x7c\xff\x01\xd3\xa5\xa2\x54\x18\x0f\xf4\xae\x06\x44\x09\x23\x34\x33\x29\xf8\x40\x86\x13\xe6\xf0\x8e\x88\x03\x2a\x75\xa7\xcf\xfe\xd1\x05\x0a\x08\xab\x87\x0d\xc8\x69\x3b\x90\x91\x66\x7c\x76\x61\xe1\x03\x35\x67\xca\xd6\xa4\x56\x6a\x47\x61\xe8\xe0\x38\x26\xcc\xaf\x87\xa6\xdb\x64\xfd\xb1\x51\x9a\xc9\x84\x61\x63\xc7\x19\x00\xc6\x74\x07\x55\x66\xac\x2b\x64\xc3\x21\xbe\xc0\x92\x49\xae\x19\x96\x24\xd8\x36\x11\x95\x48\x10\x02\xaf\x67\x60\xa9\x05\x1e\x41\x6c\x81\x18\xac\xe3\x62\x0c\xcb\x5b\xb4\xa0\x24\xf0\x55\xc6\x07\x15\xa9\x26\x12\x91\x46\x6d\x43\xd0\x0a\xdf\xab\x35\x9e\xc4\xdf\x09\x90\x53\xfb\x03\x34\x8b\xb6\x94\x0c\x4e\x79\x95\x50\x61\x07\x25\x32\xea\x95\x2b\xa3\x39\x0c\x09\xe8\x02\x59\x9f\xe9\x12\xdf\x51\x69\xed\x31\x66\x4e\xa4\x83\x3e\xdf\xc5\x42\xc7\x16\xa5\xf4\x56\x81\x8a\xbc\xee\xa5\xff\xb3\x3b\xed\xb1\xdd\x45\xc8\xea\xb4\xd1\x18\xc8\x34\xab\x40\x14\x8c\xd5\x69\x3f\x03\xf3\x19\xa8\xe4\xc2\x54\x52\xf9\x9c\x31\xda\x07\x72\x9a\x82\x9c\xee\x07\xe9\xb4\x15\x8c\x92\xa5\x02\xe6\xc9\xb8\x03\x5b\x42\x25\x3b\xa9\x3c\x0b\xe7\x3b\xd9\x8a\x3d\xbe\x2d\xf6\x68\xb9\xac\x6d\xf5\x2d\x6e\xc4\x6d\xad\x02\x08\xdd\x71\x82\xbf\x1b\x4f\x76\x8d\x71\x88\x03\xa4\xde\xae\xc0\x4c\x6f\x5f\x6c\xb2\xb9\xb6\xbe\x1b\xfe\x2e\x70\x48\x83\x6d\x3a\x8b\x76\x51\xe7\xcc\x69\x3b\x27\xff\xd8\x1f\xcc\xee\x09\x7f\x75\x28\x33\x78\xbd\x3d\x98\x25\xa2\x54\xc5\xff\x7b\xc2\x6b\xc7\x2c\x22\x26\xa3\xc1\x31\x2b\x88\x14\xfc\x55\xcb\x87\x0c\x37\x53\xcf\x0f\x5a\x3b\xe4\xd9\x4a\x11\xbd\xc1\xb3\x93\xbc\x1d\xfc\xb6\xb4\xa4\x28\x34\x9b\x61\xa2\x5e\xbe\x1f\xaa\xbe\xf3\x12\xae\x08\xb2\x10\xd1\x7b\x45\xe6\xb3\x13\xc9\xca\x59\xc1\x4e\x8c\xcf\xf8\xdd\xd8\x21\xf6\x92\xb4\x0c\x17\xa5\xaf\x22\x45\xcb\xb8\xe4\x33\x79\x15\x85\x67\xf3\x3b\x13\x56\x0d\x14\xc5\xaf\x34\x23\x28\xfc\x46\x05\x46\x65\xea\x5c\x1e\x60\x3b\x78\x22\x9b\xba\xb2\x06\xa3\x0e\x9f\x43\xe9\xa9\x66\xf1\x12\x77\x27\xd7\x3c\xc2\xa9\x07\xd7\x5f\x8e\x71\xea\x14\xfc\x55\x4e\x9d\xe1\xfe\x60\xa7\x2e\xf4\x72\xb4\x57\xe7\xa8\xba\x5b\xef\xbe\xe4\x6d\xec\x22\xec\x78\xd5\xde\xd8\xf7\xd2\xe2\x73\xcf\xda\xb3\x8a\x65\xe1\x9c\x94\xc9\xfa\xf1\x1c\xd3\xac\x7f\x10\x44\xde\xf7\x99\xa2\xd5\x68\xee\x61\x5f\xd9\x65\xf5\xe6\xfa\x0d\xfc\x27\x6a\x3d\x7a\xb5\xb8\xce\x48\x0e\x14\xc1\x03\x84\x48\xa7\x25\x6b\x70\xfd\xc5\x99\xa9\x97\xe5\x4e\xa7\x98\xe4\x16\x7f\xfa\x7e\xc9\x04\x7b\x65\xb8\xf8\xdf\xf3\x73\xbb\xba\x7b\xc9\x5b\x78\xd5\x3f\xb3\x43\x8a\x3e\x85\x1c\x4f\xaa\xb7\x99\xc9\x5b\xca\x3f\x7d\x5f\x49\x6b\xde\x65\xb2\x43\x5e\x6b\xdb\x39\x99\x0a\x86\x79\x08\xc8\x5b\x8c\x18\x50\xc0\xe9\x41\x20\xd9\xfa\xf1\x96\x00\x30\x56\x14\x8e\x89\x01\x05\xc6\xab\xc2\x80\x86\x9e\xd9\xe3\x07\x44\x02\xf5\x95\x68\xe7\xe8\x30\x10\x92\x50\x0f\x00\xa5\xed\x17\x85\xe9\x42\x12\xee\x8c\x7b\xfb\xf1\x69\x6f\x69\xf5\x20\x87\x34\xcb\x5f\xe6\x00\x68\xbe\x82\x5c\x12\x55\xf6\xd1\x29\x75\x46\xd0\xbf\x48\x3e\xa0\x8f\x67\x36\x03\xf4\x12\xaf\xbd\xac\x8c\xe0\xf5\x0a\x5e\x0a\xff\x65\x66\x59\xa8\x38\x1f\x8d\xc7\x59\xac\xe8\xff\x87\x82\x45\xa2\xb3\x20\xfa\xe1\x41\x22\x71\xe4\x2c\x40\x84\x24\x34\x42\x43\xf2\x54\x0f\x0b\x6a\x2b\xd7\x4e\x54\xc8\xf3\xfd\xa2\xdc\x02\x80\x6a\x63\x46\x1d\xfe\x73\x29\x73\xef\xb6\x92\x08\x6d\x30\x40\x7b\x6a\x24\x03\x44\xf5\xc0\x68\xd1\x7b\xd1\x6e\xec\x23\x80\x5a\xa8\xd3\x3e\x39\x35\xbf\x0a\xe8\xb4\x4b\x80\x74\x54\x72\x7e\xbd\xda\x0a\xea\xe1\xe0\x9c\x8a\xef\xc7\x04\xb3\x32\xde\xab\x42\xda\x0e\x91\xcc\x77\x7e\x40\x60\x83\x46\xb0\xda\xf1\xd9\x0d\x60\xe9\x71\xcd\xd8\x1c\x55\x58\x20\xd4\x02\x50\x69\x60\x1c\x36\xad\x1f\x56\xc0\xcf\x9b\x41\xac\xa3\xc2\xa8\x2e\x60\xf3\xf8\xc0\x71\x70\x44\x34\xdd\xfd\x19\x4d\xd8\x8f\x4f\x9a\xac\x1a\x2b\x88\x87\x23\xf6\x09\x46\xd4\xae\x22\xf2\xdb\x44\x01\x79\xe8\x7d\x79\x70\xe6\x98\x46\x15\xff\x75\x12\x2a\x0a\x49\x22\xf6\xb2\x88\xcf\xbf\x46\x48\x22\x82\xde\xb2\x27\xae\xc1\xee\xb9\xd7\x49\x0b\xa1\xf6\x45\x29\xb3\x78\x0c\x73\x18\xb0\x6a\xc0\x32\x13\x2e\x54\xcd\xc4\x2c\x05\x00\x4a\x9e\x78\xc1\x8d\x11\x58\xa1\xc1\x48\xb7\x2a\xf6\xd5\xbe\x25\xf7\xba\x1e\x8c\xce\xd5\x7b\x84\xa3\x22\x96\x8e\xf4\xba\x70\x65\x50\xc8\xf4\xfc\x83\x62\xd5\xc2\x4b\xf6\x1f\x1f\x9f\x85\xe5\x11\xe1\x35\x51\xc8\x3a\x27\xc9\xf6\x79\xd8\xb3\xb7\xdf\x65\xe8\x02\xd9\xca\xa8\x28\x35\xaa\x9d\xbd\x5a\x2b\xd8\x3b\x01\xec\x83\xae\x37\x5e\x28\x51\xa4\x1d\xd5\x23\xec\x33\xfb\x6a\x1b\x65\xbc\x9d\x10\x58\xdc\x96\x41\x73\x87\x4d\xef\x0d\x9f\xcd\x60\x32\xb7\x05\x95\xa9\x4d\x06\xe9\x02\xad\x59\x58\x09\xfd\x37\x50\xcf\x26\xfb\x77\x68\xaa\xe6\x5e\x81\xd4\xa8\x8d\x89\x8f\x00\x2d\x3b\xb7\xb0\xa0\x5c\xa8\x9b\x25\xbd\x27\x0c\x65\x9b\xaf\xd0\x66\x45\xbd\x15\x24\x3a\x31\x27\x82\x30\x99\xd2\x82\x58\x00\x9a\x24\x61\x2c\xb7\xa8\x0e\x14\x14\xb3\x8c\x5c\x8e\x7f\x8f\xf9\x16\xdd\x11\xb9\x21\x84\xa1\x64\x8f\x6c\x5a\x26\x11\xa9\xd9\xde\x41\xf8\xc0\x2c\x25\x05\xc2\xb1\xa5\x93\x7b\x12\x50\x05\x7b\xc1\xb7\x28\x4c\xb4\xf3\x2e\x65\x9f\xa3\x00\x9e\x96\xd2\xc1\x66\x8e\x60\x27\x6f\x23\x41\x91\xb5\x91\xc0\x98\x39\x4c\xaf\x48\x8d\x50\x82\xd1\xed\x63\xdb\x7a\x40\x31\xb7\xd8\xbf\x29\x96\x00\xa5\x19\xec\xd5\xdf\x1e\x15\x4d\x4c\xb4\xd7\xc5\x93\x12\x8d\x4c\xc9\x3f\x28\xa2\x98\x3a\x3b\x3a\xae\xc4\x62\xad\x8f\xd1\xca\xed\xd6\x85\x3f\xc4\x62\xbd\x33\x32\x9f\x1d\x53\x3b\x75\x9c\x6c\x01\x72\xfa\xcb\x99\x85\xea\x94\x21\xd8\xab\xad\x4d\xe2\xe9\xd6\xed\x17\xe9\xce\x23\x89\x83\xab\xb5\x8c\xd7\xf2\x1a\x34\xa0\xb1\x50\x42\xa6\x74\xf2\xa9\x2f\xbd\x57\xb3\x5f\x7a\x5d\x31\x01\x9a\x33\xf8\x4b\x32\x98\xe9\x42\x4e\xe4\xf8\xed\x09\xb9\xd4\x2f\xe4\x14\x39\x82\x02\xae\xac\xad\x6a\x12\x1b\xe4\x2b\xc4\xbf\x48\xf1\xb3\x97\x0b\x85\x9f\x69\xba\x34\xbc\x2b\x8b\xb4\xb1\x58\x1b\x51\xd6\xf4\x41\x7d\x44\x57\x1c\x8d\x79\xcb\xb0\xd6\x76\x4d\x1c\x31\xaa\x4d\xac\x57\x0d\xea\x12\x89\x1f\x3c\xa6\x35\x6d\x1d\x3d\xa0\x0b\x5c\x7d\x5c\x3f\x73\x94\xa1\x51\x81\x7a\xe4\x20\x37\xde\xb7\x57\x92\x7b\xab\xaf\x16\xfa\x3f\xc4\x6b\x8b\x13\x16\x2f\x8a\x3e\x00\xd0\x64\x65\x32\xfe\xb4\x47\xf8\x82\x5c\x1e\x4e\x8a\x26\x15\x51\x8a\xdb\x8a\xa0\x52\x90\xca\x53\x95\xa2\xc9\x18\x47\x1a\xa4\x3e\x88\xe0\x00\xd9\x5b\x46\xcd\x57\xea\x93\xe8\x98\x69\x30\x47\x78\xd5\x58\x29\xb0\x7f\xf0\x30\x01\xbd\x1c\x3d\x3e\xde\xa1\xd9\x77\x1a\xab\xcd\x7c\x11\xbb\x8b\x20\x47\x85\x43\x32\x91\x66\x71\x54\x4f\xe6\x28\xd5\x11\x67\x18\xde\x11\xdf\x27\xbe\xd3\x79\xdf\xb1\x1a\x4e\xb9\x54\xf6\xbc\x7b\x25\x05\xf5\x1c\x87\x2e\x14\x1a\xec\x5d\xe6\x52\x6c\xa8\x5c\xd5\x6d\x93\x8b\x7e\x30\xa2\x32\xb1\x5e\xc6\xc6\x84\xad\x9f\x1b\x2a\xf8\x2c\xe3\x63\xe7\x69\x6d\x81\xa0\xc9\x0b\x1a\xce\x7c\x76\x19\x9b\x41\x1f\x9e\xe9\x5e\xaa\x1d\x6b\x7c\x8b\xb3\x5e\x0f\x46\x64\x36\x3b\x3f\x2a\x6b\x2b\x50\x5e\xe5\xb0\x1a\xcb\x1f\xed\xb2\xc9\xb1\xcf\xa3\x9d\x56\xa1\xe9\x66\xaf\x38\x6f\x55\x58\x4d\x41\xbf\x31\x4f\x2b\x97\x7b\x9e\xc7\x36\x4a\x3b\x65\x39\x8c\xc0\xb9\x5b\x39\x55\xf5\xd1\x17\xea\xa0\x19\xa5\x9d\xf4\xe8\x55\x09\x5a\x4a\xed\xad\x73\xd2\x94\xdc\x53\x85\x77\x60\x2e\xa5\xd8\xe6\xc3\x49\xdd\x19\x03\x4a\xb5\x64\x43\x2a\x3d\xf9\x41\x7c\xa8\xec\xc6\x11\x83\xd5\x61\xb6\xe8\x7e\x87\xae\x02\x38\x3c\x92\xca\xad\x56\x91\xfa\x91\x79\x38\xdb\x22\x49\x10\xa4\xab\xd1\x82\x80\x3a\x2b\x98\x53\x76\xd2\x5c\x23\x7f\xda\x43\x91\x70\x08\xbb\xa7\x3c\x62\xce\x92\xc8\xba\x3d\xed\x5f\x5e\xff\xe6\x0e\x2e\x47\xda\x81\xfa\x54\x91\x74\xa1\xa3\x52\xa1\x2a\x49\x85\xc7\xa6\x7d\x32\xcf\xe2\xeb\xbd\xbd\xf1\x54\xa5\x56\xed\x6d\x2b\x08\xa5\x1b\xe3\xec\xa6\x9d\xcc\xab\xb7\xa9\x3a\x20\xc0\x78\x9c\xf8\x70\xa4\x07\x07\x9a\x26\x06\x45\xa3\xea\x1d\x9c\xdf\x20\x7e\xb6\x5d\x1b\xa2\x27\xac\x54\x17\x34\x20\xc9\x4f\x0b\xcc\xd2\x1f\x1c\x18\xa4\x3f\x38\xd0\x68\xa2\x88\xa7\xb4\xee\x29\x46\x69\xf7\xe1\x9c\x56\x13\xa8\x78\x58\x10\xd0\x6f\xb4\x5f\xe3\x99\x1e\x15\xab\xec\x0c\xc6\x73\x9a\x9c\xcc\x87\xdf\xe6\xba\x1a\x0b\xcc\x42\x81\x30\x39\xa0\x28\x26\xac\xae\x3f\x6f\x20\x2c\xd0\x62\x77\x8a\x80\x4e\xf6\xd4\x6f\xf8\x38\x41\x84\xfd\xfa\xa2\x51\xb6\x44\xbd\x0a\x27\x91\x4d\x3f\x9a\x26\x08\x4f\xa6\x8c\xd2\x16\xf2\x17\x91\xb2\x83\x33\x65\xc4\x46\xad\x52\x88\xb2\x7e\xc6\xfd\x49\xff\xf3\x70\x3c\x9c\xcc\xdd\x2f\xb3\xe1\xd4\x9d\xf4\xc7\xc3\x32\xa9\x97\x70\xae\xfb\xb3\xd9\xef\x57\xd3\x73\x1d\xaf\x91\x3a\x50\x32\xed\x68\xfb\x94\xcd\x39\xaa\xa9\x39\x73\xea\x5b\x1a\x2c\xea\x95\x36\xfe\x97\x26\xb8\x32\xfc\x8d\x9d\xfd\x9e\x85\x11\x5b\xca\xbf\x56\x91\x39\x80\xfa\x65\x0a\x73\x1c\x14\x16\x36\xc8\xe6\x5b\x5f\x14\xbd\x67\xf7\x58\xe7\xc4\xbd\x78\x7d\x18\xf1\xe2\x9d\x7a\x41\xbd\x68\xdb\x47\x1e\x7e\x4e\xe3\x20\xf2\xc9\x7b\xb9\x82\x74\xe5\xcb\xfa\x9c\x2c\x14\x9b\x0f\xa3\x0b\x90\x9a\xc4\x55\x2f\xfb\x72\xaa\xf0\xa3\x1f\x07\x11\xad\x28\x6d\x57\xbc\xd5\x3c\xb0\x0a\x9e\xe3\x14\x82\xc4\xe2\x50\xa3\x18\x4b\xeb\xa2\x9b\x66\xfb\xbe\xfe\xaa\xdf\x31\x39\x88\x4f\xc5\x42\x7d\x5f\x87\x5f\x5e\xd3\x57\x74\x78\x79\xa8\x17\x42\x5a\x59\x74\xb3\x62\xe9\x93\x93\x84\x84\xf3\x30\x9a\x5a\x6a\x5a\x90\xde\x9f\xaf\xea\x11\x4b\xa3\x93\x46\x12\xe1\x61\x56\x7f\xe6\x8c\x35\x5d\xa8\x63\xd5\xb0\x7f\xdb\xb2\x9e\x3b\x63\x34\x89\xd0\xe8\x1a\xa5\xdb\xfb\x61\xc7\x33\x50\x46\x2b\x2c\xd0\x1d\x54\x7f\xd3\xd3\xfb\x7e\x76\xc8\x88\x2e\xd4\x41\xc5\x83\xe8\x16\xc1\xb9\x38\xdc\xf8\x1c\xe1\xac\x0b\xc7\x12\xcf\xf1\xf6\x13\xd7\x35\x8b\x7a\xe9\x19\xf6\x4a\xf5\x55\x44\xdd\x17\xa3\x76\x75\x9a\x94\x6d\x7d\xa3\x4c\xd6\xd5\x9c\xe8\xaf\xc3\x58\xd4\x35\x3a\x0d\x98\x17\x28\x1c\x9d\x87\xc9\xce\x75\xa1\xe3\xb6\xeb\x86\x98\x32\xd7\xb5\x13\x05\xa4\x67\xe4\xcb\xb3\xce\xe8\xda\x9d\x5f\xb9\xb3\x41\x7f\x92\xcc\x33\x0a\xd6\xec\x0a\xea\xed\x26\x2c\xb5\x9d\xad\xc1\xfb\x1d\xa9\xa6\xed\xe6\x2d\x94\x0f\x53\xbf\xb6\xc9\x39\xe9\x1e\x71\x30\x5f\x8a\x9b\xf6\x6d\x31\xe8\xc4\x56\x38\xe4\x81\xca\x7a\xa7\x51\xfb\xff\x01\x00\x85\xcc\xd7\xcc\xf4\x4f\x00\x00")
 
 func idracPyBytes() ([]byte, error) {
 	return bindataRead(
@@ -86,7 +87,7 @@ func idracPy() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "idrac.py", size: 13980, mode: os.FileMode(420), modTime: time.Unix(1469690552, 0)}
+	info := bindataFileInfo{name: "idrac.py", size: 20468, mode: os.FileMode(420), modTime: time.Unix(1792277499, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -111,7 +112,7 @@ func idracToml() (*asset, error) {
 	return a, nil
 }
 
-var _iloPy = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x1a\x6b\x6f\xdb\x46\xf2\xbb\x7e\xc5\x56\xf9\x40\x12\xe7\x30\xb2\xec\xe4\x92\x1c\x7c\x80\x2a\xd3\x57\xe1\x2c\xd9\xb0\xd4\x7b\x40\x10\x08\x8a\x5c\x59\x6c\xc8\x25\x8f\xbb\xb4\xa3\x16\xfe\xef\x37\xb3\xcb\xc7\x52\x22\x6d\xb9\x77\x41\x51\xa0\x2c\x9a\x90\xbb\xf3\x7e\xed\xcc\x2a\x6f\xbe\x7b\x97\xf3\xec\xdd\x3a\x64\xef\x28\x7b\x20\xe9\x4e\x6c\x13\xd6\xeb\x85\x71\x9a\x64\x82\xfc\xc4\xe1\xa3\x78\x4f\x78\xf9\xc6\x77\xbc\xb7\xc9\x92\x98\xf8\x49\xba\x23\xc5\x62\x40\x69\x8a\xdf\x15\xee\x36\x0d\xa3\xa4\xd7\xeb\x4d\x47\x63\xf7\xf6\xce\xb9\x9a\xfc\xcb\xfd\xfe\x7a\x34\xfe\xfb\xf5\x64\xbe\x20\x17\x64\xd9\x23\xf0\x18\xef\x07\xf0\xdf\xb9\x71\x42\x8c\xb3\xb3\xf7\x83\x0f\x57\xf8\x36\x18\x7c\xfa\xf8\xe7\x0f\xea\x0d\x9f\xea\x6d\x8c\x6f\xc3\xc1\xf9\xe9\xfb\x33\x7c\x3b\x3d\xff\x74\x3a\x84\x5d\x45\x69\x30\x1c\x14\x94\xae\x9c\x2b\x78\x24\xc4\xe8\xea\xd3\x50\xe1\x0f\x07\x1f\xd5\xdb\xa5\x33\xba\x1c\x8e\x15\xdc\xe8\xf2\xfc\x12\xf0\x57\xbd\xde\xa5\xf3\x8f\xc9\xd8\x71\x27\xb3\xab\x1b\x77\xe1\x4c\x6f\xaf\x47\x0b\x07\xa4\xfc\x45\xd2\x7e\x43\x36\xe1\x3a\xa3\xae\xbf\xf5\x18\xa3\x91\xeb\x7b\x59\xc0\x4f\x48\x10\xf2\x2f\xf0\xd7\x26\xcc\xe2\x47\x0f\xb6\x1f\x68\xc6\xc3\x84\x11\x8f\x05\x64\x1d\x26\xbc\x5e\xc8\x28\xc9\x59\xce\x69\x50\x90\x33\xa5\x71\x48\x90\x50\xce\x0c\x41\xd2\x2c\x79\x08\x03\x4a\x78\xee\x6f\x49\xc8\x36\x89\x25\xe1\xfa\x71\x12\x00\x37\xe6\xc5\xb4\xff\x99\xf4\xfb\x4a\xd1\x3e\x15\x5b\x9a\x31\x2a\x38\x2c\x2e\x57\xc5\x62\x4c\xe3\x24\xdb\xe9\x2b\x2d\x22\xeb\xdb\xc0\xd3\xa7\x9c\x27\x59\x63\x55\xaa\xa4\x2f\x70\x9a\x85\x1e\xc8\x90\xc7\x6b\x9a\xe9\x52\xec\x6b\xad\xef\xe9\xca\x17\xeb\x4f\x3d\x67\xf1\x83\x73\x37\x73\x16\x6d\xe6\xad\x15\x3d\x21\x3c\xa5\x34\x90\x36\x2c\x59\x68\xf6\x7b\xce\x72\x05\x2d\xdd\x7e\x9e\xaf\x8b\xd5\x6e\x4e\xc9\x0f\x17\x72\xf6\x85\x25\x8f\x4c\x09\xf0\x82\x9a\x4f\xbd\xdb\xbb\x9b\xb1\x33\x9f\xdf\xdc\x1d\xea\x73\xc0\x08\xc5\x7a\x59\x7e\x5d\xf2\x52\xa8\x59\xc2\x68\x21\x89\x9f\x64\x94\x57\x4b\x4f\xbd\xa9\x33\xbd\xb9\xfb\xf7\xb7\xe1\x1e\xfe\x4c\x9b\xcc\x9b\xf2\x3c\xf5\x7a\x7e\xe4\x71\x4e\x26\x51\xe2\x64\x59\x92\x99\xce\x57\x9f\xa6\x02\xec\x63\x7d\x96\x08\x29\xec\x42\xee\x07\x74\x43\x58\x92\xc5\x5e\x04\x14\x5d\x70\x87\x09\xff\x17\x20\xf0\x06\x32\xc3\x9f\x76\x9e\xa6\x34\x33\x2d\x3b\xa3\x69\xe4\xf9\xd4\x34\xde\x62\x76\x7e\x36\x94\x34\x19\x15\x79\xc6\x10\xb0\x20\x78\x4f\x85\x0b\x5a\xb8\x21\xe3\xc2\x63\x00\xbf\x4d\xb8\x38\x21\xa0\x60\x76\x22\x19\x3f\x26\x59\x50\x30\x41\x6d\x2f\x54\x29\xb2\x41\x58\x09\x8a\x96\xb9\x50\x38\x51\x72\x1f\xb2\x8b\x26\xe6\x45\x45\x42\x67\xaf\x6a\x19\xb2\x77\x91\x7f\x95\x83\x66\xe6\x3d\xa2\x62\x50\x07\x50\xa6\x22\x4a\x0a\xee\x6f\xc8\x62\x4b\x49\xe0\x09\x8f\x70\x91\xe5\x3e\x50\xa2\x64\x93\x64\x04\x6a\x22\xf1\x82\x00\x1c\xca\x29\x2f\x58\x80\x7b\x64\x51\x55\x2e\x0a\x39\x78\x87\x0a\xb1\x23\xcc\xe3\x62\x77\x52\xd0\xa3\xe0\x08\x1f\x12\x32\xda\x49\x3a\xe1\xf5\xcd\x19\x31\x59\x42\xfc\x88\x7a\x19\x16\x24\x11\x32\x1f\xfd\x40\xd6\x54\x3c\x52\xca\x08\x85\xc4\x0d\x02\x20\x3e\x9b\x8c\xb9\x4c\xab\x70\x3e\x9e\x4f\x0a\x7a\x58\xa9\xb9\x65\xcb\xaf\x4a\x27\xac\xce\x2b\x65\xbe\x8d\xae\x15\x84\x08\x59\x1a\xc0\x73\x88\xfe\x41\xde\xc6\x4a\x29\xda\x02\x7b\x71\x51\x80\xd4\x10\xf8\x80\xcb\x32\x70\x5f\xf0\x15\x98\x0c\xaa\x1d\x1a\x71\xda\x0d\x77\x5a\xed\xa0\xce\x31\x8a\x51\x5a\xbd\x89\xb4\x09\x69\x14\xa0\xf8\xb1\x0d\x4e\x32\x0d\xf5\x0d\xc2\x2e\x57\x56\x13\x10\x6d\xa7\xe8\xb0\x7b\x6a\x56\xcc\x20\x22\x28\x33\x15\x9a\x75\x42\x86\x56\x93\x7e\xa1\xa6\x79\xb0\x58\x33\x5f\x86\xab\xa5\x81\x11\x66\xac\xa4\x09\x6e\xc1\xc0\x06\x9a\xfd\x25\x9c\x07\x2f\xca\x11\xe9\x3b\x65\x37\x03\xbd\xb3\xa6\x51\xc2\xee\x39\x11\x09\x89\xef\x63\x51\x86\xcc\x01\xa9\x16\x31\xf1\x51\x19\xd6\xcc\xbf\x92\x23\xf9\x13\x39\xad\xb9\x5a\xad\xf8\xa0\x2a\xa0\x2c\x3f\x7f\x58\x01\x11\x81\xe6\x6a\x3b\xcb\xdb\x79\xe3\x03\x01\x05\xfc\xcb\xc6\xc0\x3c\x38\x00\xda\xb9\x16\x98\x4b\x03\x78\xa3\x11\x65\xe6\x3f\x03\xa8\x62\xd6\xf6\xa0\x88\xb0\xc0\x84\x05\x45\x96\x46\xed\x01\x79\xae\x05\xe4\x0b\xe1\xf4\x3a\xf9\x1b\x32\xef\x15\xbd\xa5\x01\x96\xdb\x37\x73\xb7\xec\x45\xcd\xa9\x00\xf4\xca\x23\x2b\xb1\xc9\xab\x0a\xc3\xbd\x38\x8d\x68\x89\x22\xfd\xa9\xd5\x11\x38\x00\x86\xef\xcf\xce\xc8\xf4\x87\x9f\xfb\x65\x46\x73\x2c\x2e\xe8\x50\x2c\xe6\xb5\xc6\x98\x37\x21\x13\x26\xb7\x79\x1a\x85\xc2\xec\x93\xbe\xb5\x1c\xac\x1a\x12\x35\x24\xa9\x3b\x08\x59\x04\xf1\x13\xc5\x92\xf0\x65\x9d\x96\x87\x96\xe9\x6b\x01\x7a\x84\xc4\xe7\x24\xd9\x90\x73\x22\x71\xff\x42\x3e\x12\xb1\xcd\xa8\x07\xdd\x8b\x5e\x6a\xfc\x76\x25\xf0\xf1\x0b\x45\xfc\x4a\x11\x20\xa7\xe9\xa2\xe9\xe3\x2b\x69\x6b\x4d\xea\xd2\x87\xb1\x91\x96\xb1\x21\x75\xab\xb9\xe0\xa7\x1e\x19\x87\xbd\x80\xd5\x80\x5d\x1a\xd2\x6b\x32\x32\x34\x27\xa6\xaa\x4a\xcd\xe5\x9e\xb5\x8f\x22\xd5\x97\x28\xb5\x21\x0b\x0c\xe7\x2b\xf5\x73\x59\xe5\x17\xd4\xdf\xb2\x04\x8e\xb1\xdd\x3e\x01\xa5\x4f\x19\x5a\xb8\xd2\xf0\x64\x0d\xa2\xbb\x54\x75\x91\xea\x4c\x93\xaf\xfb\xfe\xc4\xce\xa0\x0a\xbe\x63\xdd\x39\xf8\xf4\x81\x4c\xbf\x6f\xb8\xaf\x23\x06\x5f\x8c\xc3\x66\x2c\xe2\x97\x92\xb3\xe9\xb7\x3a\xa7\xe5\x66\x4d\x1f\xbe\x75\xbf\xed\xb5\x50\x96\x0e\x07\x3e\x03\x5d\x2b\xfb\x4b\xc5\x8b\x63\x65\x8e\x1b\xd6\x3e\x74\x9b\x87\xe3\x0e\x0f\x2b\xb9\x4a\xe7\xc0\x57\xb3\xd5\x91\xbb\xe0\x97\xd6\xfe\xa1\xd9\x2d\xa8\x81\xed\x6d\xd5\x34\xfc\x27\x0f\x05\x05\xf5\xfd\x84\x3d\x50\x16\x52\x26\xa4\x45\x92\x3c\x03\x6a\xd0\xe8\xbc\xf5\x3d\x0e\x4d\x36\x56\x16\x0a\x1b\x94\x3c\x52\xc2\xb0\xe1\x86\x33\x06\x22\x6c\xeb\xa5\x80\x0d\x27\x0d\x01\xd3\x0b\x70\xeb\x3a\x14\xb6\x8a\x0f\x68\x47\x52\x6c\x84\xb1\x73\x72\x51\x26\x19\x26\xd5\x57\x5b\xff\x53\x6d\x56\xcd\x29\x3e\x7d\x98\x1f\x5d\xec\x36\xb5\x39\x43\xae\xb7\x8e\x24\x72\xe7\x60\xba\x51\xab\x9e\xef\x56\x5d\x94\xb6\xf9\xd4\xd6\xbb\x14\xe5\x7f\xb8\x57\xfe\x41\x25\x51\x46\x4b\x25\x6e\x33\x20\x81\x10\x42\x15\xae\xcc\xd7\x3f\x51\x5f\x18\x96\x24\x38\xdf\x71\x01\x21\x35\x01\x65\xa0\xde\x63\x46\x1a\x87\xc7\x61\x45\x16\x82\xa4\xd0\xdc\x58\x55\x89\x09\x94\x0f\xcf\x41\xf0\x1e\x34\x72\x39\x3d\x56\x8e\xdb\xd2\x72\xc7\x8b\x52\x1b\xfb\xd7\x0a\xd3\xde\x06\x75\x89\x38\x55\x79\x7a\x49\x1f\x42\x9f\x76\x77\x44\x1a\xba\xcc\x32\xd9\x0f\xa9\xee\x03\xba\xb4\x28\x82\x3c\x6a\xe0\xb5\xb4\x3e\x9a\x92\x2a\x6e\xfe\x9f\xd6\x86\x14\xc3\x92\x25\x1b\xe9\x7a\xbf\x68\x34\xad\xee\xaa\x86\x4f\xd5\x9e\x1e\x22\x1e\xc2\x82\x2f\xe5\x26\x06\xa7\x82\x6a\x6f\xb4\x3a\x1d\x51\x01\xf0\x6a\x50\x92\x74\xf0\xde\xc2\x17\x56\xa7\x07\x1a\xd2\xd6\x7d\x29\xfa\xd0\xd1\x66\x09\x39\xc3\x8c\x38\x0f\xef\x59\x0c\x55\xc6\x68\xa5\xd5\xd1\x98\xe2\xa3\x7b\x49\xcf\xe3\x97\x9c\x55\x3e\x6b\xe8\x09\xbe\x3c\xef\xca\xae\x16\xf0\xec\x7f\xac\x01\x90\x6d\x01\x14\x63\x32\xc3\x36\xff\x05\x9f\x7f\x93\xe4\x6f\x3f\xff\x8f\x16\xe4\x5b\xa4\x7e\x2d\xdc\xb5\x07\x63\x4b\x53\x9a\xd6\x58\x3b\xc8\xf4\x57\x20\xa8\xf3\x54\xc7\xf8\x0d\x8a\xc2\x91\x49\xaf\x27\xf2\x21\x72\x7b\x82\xa8\x83\x1f\x44\x08\x42\xe9\x66\x3c\xad\x4b\x36\x10\xcc\x3b\x38\xaa\xa3\xd0\x5b\x47\x70\x92\xaf\x73\x01\xa7\xb9\x27\x0c\x8e\x87\x7a\x07\xb5\x84\x45\x3b\xf2\xe8\xed\xf0\x94\x57\x97\x04\xf7\x79\xc8\xb7\xd5\x25\x41\x29\x0e\x9c\xfa\x5c\x48\xd5\xbd\x90\x01\x50\x07\xb9\xe6\x9d\xc2\x03\xb7\xd5\x9d\x02\x57\xd7\x09\xf6\x6f\x52\xa7\x8e\x9e\xb9\xf7\xf0\xf4\xfa\x86\x73\xf7\xef\xa8\x8e\xed\x8f\xb2\x7f\xd4\xb1\x3f\xea\xd8\xaf\xf0\x02\x5e\x4d\x1c\x6f\xf4\x57\x05\xf9\x5e\x18\xeb\x97\x7c\x99\x17\x72\x5a\x5f\x20\xf7\x7f\x64\x3c\x4f\xb1\x7e\x40\x59\x29\xe3\x1c\xe6\x76\x08\x73\x62\xfe\xf2\x64\xd9\x7d\x5b\x35\xb6\xa6\x3e\x66\x58\x65\xa7\x8f\xd7\x76\xad\x21\x6a\x91\xbf\x92\xd3\x4e\xae\x0d\x81\xfb\x0b\x59\x66\xf9\x36\xc9\xa1\x5a\xaf\xa9\xaa\x9a\xe8\xc5\xb6\x3e\x5f\x56\x27\xd2\x6f\x52\x80\xe4\x13\xe5\xc4\x56\xcd\x69\xeb\x5d\x63\x4a\xb3\x6b\x9c\xc6\xd4\x57\x89\x5f\x0c\xe4\xa8\x67\x20\xbb\x65\xa9\x8a\xd4\x3b\xf6\x98\x77\x8f\x97\xd5\x87\xb3\x56\x23\xe9\x71\x84\xae\xc1\xd1\xcd\xda\xd8\x66\x1d\xcc\x66\xaf\x9b\xed\x8a\x0b\x81\x4a\x32\x20\xd0\xf6\xeb\xd9\x3e\x58\x33\x5f\xcb\x39\x59\xbb\x44\xea\xc8\xec\x03\x7e\x4b\xa3\xba\x18\x93\x74\x6a\x2f\xee\x5d\xcd\x77\x47\x6d\x8b\x42\x2d\x6c\x1a\xbf\x7d\xed\xb1\x6a\x0d\xb6\xe5\x60\x55\x24\xb6\xc4\x24\x33\x85\x79\x42\xfa\x7d\xcb\x86\x09\x3e\x4c\xcd\x4e\x66\xf5\xaf\x36\xaf\xe2\xd4\xa8\xe1\x92\x51\x27\x83\xa2\x5c\x94\x96\x2f\xee\x7a\x5a\x0a\x4a\x23\x2c\x35\x1a\x45\x60\x72\xdf\x63\xcf\xfe\xe6\xb2\x91\x32\xe3\x29\xd5\xef\x77\x27\xfc\x2c\x21\x93\xdb\xf2\x4a\x1b\x1b\x13\xa4\x4b\xb6\x1e\x87\xd4\xa3\xac\xfc\x6d\x2a\xb0\xfb\x55\x92\x23\xb7\x23\xa8\xaa\xa8\xc7\xa1\x44\x62\xa0\x49\x9f\x25\x5b\x8a\xff\x3a\xd2\x15\x56\x37\xe9\x3a\x03\x8b\x4b\xa4\x97\x7f\xb5\x52\x57\x58\x8f\xf5\x49\x7f\x90\xc7\xf5\xa6\x59\xb3\xa9\xc1\xeb\x6d\x15\x1f\xb5\xc4\x75\xaa\x19\x6d\x29\x7c\x74\xb9\x29\x6e\x4c\xf1\x8a\x0e\xff\x79\x80\x1d\xe4\x71\xca\x4d\x0d\x15\xaa\x72\xaf\x07\x76\x75\x65\x34\xbb\xae\x6c\x57\x5c\x20\x16\x32\xd7\x35\xea\x9b\x21\x60\x9a\x70\x9b\xb2\x87\x30\x2b\xa5\x9d\xdc\xba\x8b\x1b\x77\x3e\x1e\xcd\xb4\x58\x56\x6e\x3f\x80\x9d\x8e\x66\xa3\xbf\x39\x53\x67\xb6\x70\x7f\x9c\x3b\x77\xee\x6c\x34\x75\x34\xac\xda\xab\x2f\x62\xde\x8e\xe6\xf3\x7f\xde\xdc\x5d\x6a\xd8\x42\xbf\x35\xec\x0e\x78\x75\xae\xc9\xdf\x3e\x89\x59\x46\xca\x49\xfd\x7b\xe3\x38\x89\xe3\x9c\x85\xbe\x3c\x32\xe4\x26\xf4\xb3\x9c\x50\xfd\x2a\x19\x0d\x49\x6d\x2f\xbb\xe7\x8d\x5b\x4e\xc8\x75\x9b\x7e\x0d\x85\x79\x6a\xf5\xfe\x1b\x00\x00\xff\xff\xea\x00\xdd\x31\xa5\x21\x00\x00")
+var _iloPy = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x5a\x5f\x6f\xe3\x38\x92\x7f\xd7\xa7\xa8\x75\x1e\x24\xe3\x3c\xee\x24\x9d\xee\x9d\xc9\xc1\x07\xb8\xdd\xce\x4e\xb0\x89\x1d\xc4\x9e\x99\x5d\x04\x81\x40\x4b\x65\x9b\xdb\x92\xa8\x25\xe9\xa4\x3d\x8b\xfe\xee\x87\xa2\x28\x89\xb2\xe5\xc4\xd9\xdb\x7e\x38\x60\xda\x0d\x44\x7f\xea\x3f\x7f\x2c\x16\x4b\x3c\xf9\xd3\xbb\x8d\x92\xef\x16\x3c\x7b\x87\xd9\x13\xe4\x5b\xbd\x16\x99\xe7\xf1\x34\x17\x52\xc3\x3f\x94\xc8\xca\x6b\xa1\xca\x2b\xb5\x55\xde\x52\x8a\x14\x22\x91\x6f\xc1\x3e\x8c\x11\x73\xba\xaf\x78\xd7\x39\x4f\x84\xe7\x79\x27\xf0\x2b\x4a\xc5\x45\x06\x62\x09\x7a\x8d\xa0\x22\xc9\x73\xad\x20\x12\x99\x96\x2c\xd2\x10\x28\x44\x90\x2c\xc9\xd7\x3f\x44\x09\x87\x58\x44\xaa\x0b\x7a\xcd\x95\x25\x25\xca\xa5\x90\xa9\x02\x2d\xfa\xde\x68\x3a\x99\xdf\x0f\x47\xf3\xf0\xd7\xf1\xfd\xec\x7a\x3a\x81\x01\x9c\x79\x27\x30\x12\x69\x2e\x32\xcc\x8c\xe0\x24\xc1\x48\x63\x0c\x8b\x6d\x43\x4e\x60\x6c\x82\x58\xa0\xca\x7c\x0d\xb9\x14\x4f\x3c\x46\xe0\xd9\x52\x00\x5b\x88\x8d\x26\xfb\xbc\x13\x10\x7a\x8d\x12\x44\x86\xaa\xdb\xf7\x86\x37\x37\xe1\x68\x7a\x7b\x37\x9d\x8c\x27\xf3\x19\x0c\xe0\xc1\x47\xbd\xf6\x7b\xe0\xa7\x98\xd2\x9f\x28\xdf\xd0\x9f\x5c\x99\x3f\x92\xf1\xd8\x7f\xf4\xbc\xdb\xe1\x28\xbc\xbb\x1f\x5f\x5d\xff\x2d\xfc\x74\x33\x1c\xfd\xf5\xe6\x7a\x36\x27\x66\x0f\x00\xc0\xff\x70\xfa\xe1\xf4\xc3\x05\xd1\xbf\x7f\xff\xe1\xf4\xe3\x15\x5d\x9d\x9e\xfe\xf4\xe3\x9f\x3f\x16\x57\xf4\xab\xae\x46\x74\x75\x7e\x7a\x71\xf6\xe1\x3d\x5d\x9d\x5d\xfc\x74\x76\x7e\xea\xf7\x0a\x49\xa7\xe7\xa7\x56\xd2\xd5\xf8\xea\xea\xca\x48\x3a\x1b\x5e\xfd\x44\x14\xe6\xed\x8f\xc5\xd5\xe7\xf1\xf0\xf3\xb9\x91\x74\x35\x1e\x7e\xbe\xf8\xec\xf7\xbc\x47\xcf\xfb\x3c\xfe\xf5\x7a\x34\x0e\xaf\x27\x57\xd3\x70\x3e\xbe\xbd\xbb\x19\xce\xc7\x30\x80\x7f\x19\xd9\x27\xb0\xe4\x0b\x89\x61\xb4\x66\x59\x86\x49\x18\x31\x19\xab\x1e\xc4\x5c\x7d\x51\x3d\x58\xe5\x1b\xd5\x83\xec\x29\xc5\x30\x96\xfc\x09\x55\x0f\x96\x5c\xa6\xcf\x4c\x62\xf8\x64\x47\x9c\x65\xb1\x95\xb4\xe0\x42\xd5\x8f\x25\xc2\x26\xdb\x28\x8c\x0f\x0d\x89\xda\x44\x6b\x33\x2e\x5d\xc3\xdf\x49\x45\x8c\x49\x98\xb1\x14\x3b\x97\xd0\xe9\x14\xbe\x77\x90\xc6\x29\x43\xad\x3a\x97\xf0\xf0\x68\x1f\xa6\x98\x0a\xb9\x75\x9f\xb4\x78\xe1\xbe\xce\xa5\x88\x50\x29\x21\x1b\x62\x8c\x97\x0d\x32\xf1\x8c\x32\x54\x9b\x3c\x4f\x38\x36\x48\x95\x16\x92\xad\x30\x34\x80\x16\x49\x82\x4d\x49\x14\x28\xf7\xde\x09\x99\xfb\x58\xa1\xe4\x2c\x09\xb3\x4d\xba\x40\xe9\x7a\xb9\x1b\x55\xf7\x9d\x1b\x56\xfb\xfc\x9b\x37\x9e\xff\x3c\xbe\x9f\x8c\xe7\x6d\x23\x5a\x07\xb2\x07\x2a\x47\x8c\x81\x65\x71\x35\x70\x47\x8e\x8c\x95\xe5\x8e\x0f\x8b\x5c\xb3\xda\x87\xcb\xe8\xa3\x07\x9b\xec\x4b\x26\x9e\xb3\xc2\x80\x57\xdc\xfc\xe6\xdd\xdd\x4f\x47\xe3\xd9\x6c\x7a\xbf\xef\xcf\x9e\x22\x72\xf1\x6d\xc8\x2a\x8d\x9a\x88\x0c\xad\x25\x91\x90\xa8\x9a\x8f\x94\x88\xbe\xa0\xae\x4c\xba\x1d\xdf\x4e\xef\xff\xfe\x7d\xec\xe1\xbf\xe3\x8e\xee\x7d\x0b\x55\x22\x6a\x63\xee\xa6\xbf\x8d\xef\xc3\xd9\x2f\x77\x77\x37\xc7\x98\x54\x3c\x7d\x66\x5a\xb3\xd5\xae\xa6\x37\x42\xf0\x9b\x37\x9b\x4f\xef\x87\x7f\x19\x87\x26\x29\x4f\x6f\x6e\xc6\xc7\x0c\xd2\x8b\x32\xcd\xbb\x88\x45\x6b\x0c\x1b\xa1\xf8\xe6\x79\x51\xc2\x94\x82\xeb\x44\x8c\xa5\x14\x32\x18\x7f\x8d\x30\xd7\x5c\x64\xdd\x4b\x23\x31\x67\x4a\x79\x9e\x17\xe3\x12\x32\x21\x53\x96\xf0\xdf\x31\x4c\x59\x14\xa4\x2c\xb2\x24\x29\x8b\x60\x00\x29\x8b\xfa\x9b\x3c\x47\x19\x74\xfb\x12\xf3\x84\x45\x18\xf8\x3f\x50\x6a\xbc\xf4\x8b\x51\x90\xa8\x37\x32\x23\x42\x2b\x70\x85\x3a\xe4\x89\x08\x79\xa6\x34\xcb\x22\x0c\xd6\x42\xe9\x1e\x6c\x14\xca\x9e\x51\xfc\x2c\x64\x6c\x95\x10\xea\x06\x60\xd0\xd7\xbf\x4e\x84\x21\x25\xe7\x07\x74\xd1\x83\x44\xac\x78\x36\x68\x72\x0e\x2a\x11\xae\xfa\x62\xfd\x24\x7f\x42\xd2\x5f\x65\xbb\x40\xb2\x67\x72\x4c\xf5\x80\x6c\xb2\xf1\xb3\xda\x4f\x60\xbe\x46\x88\x99\x66\xa0\xb4\xdc\x44\x7a\x23\x11\x96\x42\xc2\xed\x70\x04\x2c\x8e\x25\x2a\x85\xca\xaa\xc0\x18\xcc\x42\x6e\x8c\x05\xae\x20\x97\xa8\xf5\x16\x32\xa6\xf4\xb6\x67\xe5\xa1\xca\x31\xe2\x2c\x49\xb6\x46\x0e\xbf\x99\xbe\x87\x20\x13\x10\x25\xc8\x24\xad\x06\x9a\x67\x11\x8d\x03\x2c\x50\x3f\x23\x66\x80\xe9\x02\xe3\x18\x63\x98\x5c\x8f\x94\x49\x30\x7c\x36\x9a\x5d\x5b\x79\x54\x1d\xd0\xc2\x4a\x77\x95\x4f\xb4\x34\x3e\x1a\x02\xbe\x74\xbd\x02\x9e\xc1\x83\xcf\x6f\xa6\xe7\x34\x3e\xa4\xdb\x7f\x2c\x1c\x6d\xa1\x1d\x0c\x2c\x49\x4d\x41\x3f\xa5\x99\xd4\x21\x8f\xbf\xc2\x00\x4e\xab\x37\x98\x28\x3c\x4c\x77\x56\xbd\x21\x9f\x53\x32\xa3\x8c\x7a\x93\x69\xc9\x31\x89\x15\xa1\xaa\xbf\x42\x1d\xf8\xc5\xbd\xdf\x83\x87\xc7\x6e\x93\x90\x62\x57\xc8\xc9\x56\x18\x54\xca\x7a\x90\x60\x16\x14\x6c\xdd\x1e\x9c\x77\x2f\x1b\x6c\xd6\xcd\x60\xef\x61\xad\xfc\x81\x3f\x3e\xf8\x84\x30\xff\xd1\x84\xe0\x4e\x48\xed\x57\x6b\xef\x0b\x3c\x4f\x2c\xd9\xa0\xff\x08\x7f\x2a\xe2\xe6\xd3\x68\x2f\x30\x11\xd9\x8a\x4a\x2d\x48\x57\xa9\x2e\x21\xb3\x27\xaa\xc5\xcc\x7a\x86\x35\xe7\x5f\xa9\x11\xfe\x0b\xce\x6a\xad\x5d\xaf\x85\x1d\xf8\x92\x66\xdd\xc3\xe5\xc7\x47\xc8\x84\xa6\x70\xb5\x15\x52\xed\xba\x2d\xa0\x60\x50\x15\xa3\xc1\xde\x52\xd8\x7d\x89\xf3\xc1\x4f\x59\x44\x41\x24\x1b\x5e\x22\x2c\xaa\x8e\x3e\xcb\x73\xcc\xe2\x00\xf5\xba\x10\x8b\x49\x3b\x20\x2f\xfc\xcb\x63\xe1\xf4\x36\xfb\x1b\x36\xef\x24\xbd\x07\xff\x76\x38\xda\x0d\xf3\x61\xdb\x6d\xce\xa9\x08\xdc\xcc\x63\xd6\xc8\x40\xd9\x21\x3f\x01\xc5\xd2\x3c\xc1\x92\xc5\xa0\xc8\xc9\x23\x97\xd0\x39\xff\xf0\xfe\x3d\xdc\xfe\xfc\x7b\xa7\x9c\xd1\x8a\x92\x0b\x0d\x28\xad\x36\xb5\xc7\x34\x6f\x78\xa6\x03\xd5\x57\x79\xc2\x75\xd0\x81\x4e\xf7\xe1\xf4\xb1\x61\x51\x99\xd3\x4d\x0e\xac\x6b\x35\x93\x04\xe9\x96\xcc\x32\xf4\x65\x9e\x36\xcb\x77\x50\xa6\xfc\x23\x2d\xbe\xa0\xdd\xc9\x05\x18\xde\xff\x86\x1f\x41\xaf\x25\xb2\x58\x75\x2a\x19\x7c\x09\x51\xbb\x13\xf4\x8b\xac\x23\x51\xe5\x88\x58\xba\xbe\x38\xfe\x44\x9e\x91\x59\x7b\x52\xa7\x3e\xc2\x46\x5e\x62\x83\x08\x1c\x70\xd0\xad\x8b\x8c\xfd\xaa\xa8\xd6\x44\xb4\x0f\xbe\x19\x35\x83\x0c\x67\x10\xf3\x22\x4b\xcd\xe8\xc6\xef\xee\xb2\x18\xf7\x0d\x4b\x1d\x48\xcb\x31\xfe\x8a\xd1\xc6\x64\xf9\x39\x46\xeb\x4c\x24\x62\xb5\x75\x05\x1c\x11\xe3\x3b\x72\xe1\xac\xb3\x6b\xa6\x29\xad\x8c\x52\xab\xea\x86\x2d\x30\xf1\xbb\x20\x24\xf8\x7e\x83\xba\x08\x58\x89\x5d\xe2\x6f\x40\xa5\x26\x71\x31\x53\x6c\x08\x0c\x5e\x8a\xcb\x5d\xc0\x50\x9d\x11\xa8\x37\xe2\xe5\xf4\xa7\x8f\x70\xfb\xa9\x81\x8f\x03\x20\x7f\x15\xe8\x8e\x07\xaa\xb0\xac\xb0\xb3\x09\x8c\x3a\x69\x98\x97\xb5\xfc\x14\x53\x17\x18\x3b\xb5\x69\xad\x22\xc5\xf4\xc1\x27\x5f\xab\x01\xa6\x9b\xc0\xae\x5b\x33\x7a\xe1\x8c\x67\x41\xdd\x06\xa1\xf4\x00\x84\x8e\x41\xc0\xfd\x74\x04\x67\xf0\xf9\xfa\xf6\x16\xde\x77\x20\xa0\xc5\xfa\x1d\xbf\x99\x5e\xd0\x60\x3b\x82\x3a\x86\xe2\x6c\x58\x90\x9c\xef\x5a\x95\x08\x6d\x8c\x4a\x5f\xc2\x4b\x11\xc3\x12\x2b\x29\xa6\x0d\xa8\x14\x6f\x1b\xa9\xa5\xb1\xbf\x33\x70\x59\x23\x4b\xf4\x7a\x17\x2e\xb6\x74\x7e\x73\x86\xf9\x78\x0a\xbf\x31\xad\x77\x73\x0a\x95\x47\x75\xda\x30\xb0\xe8\x73\x15\xf3\x15\xd7\x81\xa3\xc1\xb1\xbd\x99\x68\xda\x81\x44\x29\xca\x26\x9a\x86\x5f\x07\x92\x4d\xe1\x69\x11\xce\x26\x83\xdf\x83\x7f\x7d\xeb\xf6\xcd\x60\x2a\xd7\xa0\x13\x18\xa7\xb9\xde\xc2\x82\x6d\x95\xd9\x37\x4a\xa4\xca\x0e\x63\xd0\x42\x40\x80\xfd\x55\x1f\x3a\x13\xa1\xe1\x9a\x6a\xe6\x24\xc1\xb8\xd3\xed\xbb\xae\xdb\xd9\x9e\x4b\x54\x98\x69\x2a\xef\xfe\x8e\xca\xef\x9a\x6a\x84\xae\x9a\xbe\xd3\xe6\x9a\x67\x1b\xac\x1e\xe6\x6a\xd3\xc8\x87\x6d\xbb\xa0\x3a\x2c\xb9\xda\x3c\xf8\xf5\x46\xc4\xc0\xa7\x4c\x6d\xe6\x71\x89\x9f\x6e\x5f\x69\xc9\xf3\x60\x87\xd5\x0e\x7a\x35\x75\x4a\x10\x58\x11\x11\xcb\x59\xc4\x75\x23\x23\x1a\xbe\xc6\x96\xaa\xa1\xb5\xf9\xe6\x65\xed\xbb\x7b\xa5\x86\xa0\xbd\x97\x87\x65\x35\x46\xb6\x4a\xa3\x6a\xd3\xcc\xa2\x0d\x2a\x77\x8a\xb4\x34\x3a\x5e\x98\x27\xf5\x26\xee\xad\x53\xe5\xec\xf4\xe2\xc7\x0f\x7f\xfe\x08\x7f\xfd\xf4\x5d\xe6\x0a\xbc\x7b\x07\x67\xa7\xe7\x17\xde\x0e\x6d\x3d\x67\x1c\x07\xeb\x09\x73\x02\xf7\x25\xc2\x17\x5b\xa0\xac\x05\x22\x4b\xb6\x3d\x60\x0a\x18\xc4\x3c\xd2\x54\x43\xb8\xac\x5f\x70\x5b\x36\x21\x91\x4b\x48\x28\x4f\x29\x2b\xcb\x4e\x90\x51\x45\x0e\x22\x83\xd9\x56\x69\x4c\xe1\x93\x60\xb2\x9a\x2d\x34\x4f\x23\xca\xfd\x4e\xa8\x2d\x82\x8a\xe1\x28\x90\xdb\x3e\x49\x39\xed\x85\x35\x70\x55\x6d\x5c\x23\x6a\xe0\x45\xba\xfb\xca\xec\xaa\xdd\x70\x27\xd9\x0b\xdb\xfd\x6e\x0b\xeb\xfe\x8c\x8b\x8e\x9a\x71\xae\x84\x76\xe8\x5b\x39\xcb\xe7\xd7\x41\xef\x0a\xab\x21\x59\x4d\x64\x07\xa5\x15\x0b\xfd\xb7\x1a\x8a\xd7\xa9\x88\x37\x09\xda\x95\xd7\xef\xf6\x2a\xca\x36\x35\x55\x6d\x5d\x3f\x6a\xcc\x2f\x87\xd2\xb4\xc7\x5b\xf6\xeb\xcd\xdd\x79\xd1\x94\xff\xa1\xda\xa4\xff\x73\xc3\x35\x75\xad\x23\x91\x3d\x61\xc6\x31\xd3\x66\x63\x2e\x36\xd2\x3b\xa1\x96\xc4\x0f\x11\x53\xd8\xa3\x5e\xb6\xc4\xa5\x90\x08\xcf\x08\x19\xb5\xfa\xb4\x00\x89\x6a\xcd\x72\x04\xae\x81\x41\xc2\xb5\x4e\x10\x16\x5c\xf7\x8b\x49\x9e\x4b\xcc\xa9\x7f\x4b\x9d\x8a\x90\x6c\x2a\x30\x57\xde\xb5\xf5\x1b\xaa\x97\x55\xbf\x87\xfe\x77\xd4\x56\x85\xd4\xd5\x72\x3a\x9c\x87\x9b\xad\xed\x7d\xdb\xb2\xb1\x18\x56\x5d\x0b\xe7\xe5\x37\xef\xf0\xfe\xff\x7c\x67\xbb\x95\x33\xa9\xab\x85\xae\x34\xb7\x89\x7f\xbe\x34\x54\xb6\xb2\xd9\x2c\xfe\x81\x91\xf6\xbb\x66\xff\x66\xe7\xe4\xb5\xf9\x04\xc1\xa8\x02\xde\x59\x99\x1a\x51\x78\xf0\x4b\xcf\xfd\xc7\x2a\xc1\x32\xa9\xbb\xde\x0e\xc7\xfe\x94\x7b\xc5\x8e\xbb\x32\x72\xc7\x9b\x52\x07\xfb\xdf\x35\xa6\xbd\xed\x70\xc8\xc4\x5b\x33\x84\xf0\x19\x9f\x78\x84\x87\x3b\x10\x0e\xbb\x29\x3a\xcd\x8a\x6f\xd2\x54\x59\x29\xf8\xde\x2b\xad\x06\xc7\x49\x3b\x29\xff\x83\xd1\xe6\xca\x54\xf0\x64\xbf\xf3\xde\x36\x76\xba\x87\x8b\xfc\xba\xbb\x02\x83\x16\xc6\x7d\x5a\x21\x0b\x7a\x02\xa7\xb9\xd8\x69\x06\xbc\x3a\x10\xe5\xcf\xc9\xef\x46\x8e\xcd\xf1\x07\x47\xa0\xfc\x19\xe2\xba\x0f\x44\x63\x38\x76\x7a\x77\xa6\x67\x38\x54\x8a\xaf\xb2\x94\x4a\xb4\x56\x59\x2d\xa3\xd3\x36\x4a\xee\x3c\x7e\x0d\x8d\xe5\xbf\x85\x44\xf6\xe5\xe5\xa1\xc4\xe4\xf5\x1e\xe0\xbf\x93\x03\xee\xa4\x88\x37\x91\x86\x09\xb5\xd5\x5e\x19\xf3\xef\x32\xf9\xdb\xf7\xdb\x47\x1b\xf2\x3d\xa6\x7e\x6d\x5c\xb9\xe3\x72\xac\x69\xc5\x5a\xcd\x51\x6c\x2f\xdf\xc2\x50\x6c\x2f\x5d\x0e\xef\x15\xd8\x7d\x87\xa4\x70\xe4\xa4\x77\x27\xf2\x3e\xf3\x3e\x43\xdd\xa8\x8f\x44\x16\x73\x5a\x54\x80\x56\xeb\x52\xcd\x13\xca\x2d\x48\x4c\x38\x5b\x24\xd8\x83\x85\xf9\x32\xcd\xb4\xaf\x68\x51\x3f\x20\x8d\x6a\x51\x78\x66\x5b\xd0\xc2\x36\xe5\x57\x1b\xae\xd6\x55\x53\xbe\x34\x07\x12\xae\xb4\x71\x9d\xf1\x8c\x67\xab\x03\xe2\x9a\x3d\xfc\x27\xd5\x2f\x7a\xf8\x0a\xa8\x04\x56\x7d\x6f\x87\xe1\x30\x6a\xfe\x83\x79\xea\xe8\x1e\xf7\x0e\x9f\x9b\xdf\xf8\xcd\xf4\xff\x53\x1e\xbb\xf8\x23\x8f\xfd\x91\xc7\xfe\xef\x79\x8c\x3e\x05\x1c\x1f\xf4\x37\x81\xbc\xa1\xbc\xf9\x51\x4d\x32\xae\xb0\xfe\x60\xdb\xf9\x25\xa3\xe3\x13\xc5\x16\xba\xc4\xb9\x58\xd2\x56\x1a\x02\xda\xbf\x76\xfa\x45\x61\x1b\x38\x13\xc1\x36\x54\xf8\xd2\x7c\x26\x6b\x85\x68\x17\xfe\x07\xce\x0e\x6a\xad\x9e\xd3\xff\xce\xdc\xa4\x59\xb5\x16\x9b\x24\x86\x05\x9a\x1d\x3c\x9d\xf4\x69\xad\xf3\x4d\x15\x05\x75\x0f\x82\x7e\x1d\x9e\x51\x12\x2e\xbe\xb0\x56\xfb\xb4\xc5\xb6\xb1\x4b\xeb\xd7\x3c\x8d\xad\x5f\x65\xbe\xed\xaa\x48\xfc\xe7\x06\x95\xc6\x38\x8c\xaa\xe3\x4b\xe5\xf6\xfd\x04\xa6\x49\x8c\xb2\x0c\x94\xa2\xe6\x82\x7b\x48\x8a\x0e\x35\x68\x4c\x12\x78\x5e\xf3\x68\x0d\xb5\x00\xdb\x8c\xb3\x92\xfb\xb6\xa1\x51\xbd\x1d\x80\x50\x7d\xcc\x9e\xb8\x14\x59\x81\xe9\xfb\xe1\xcd\xdd\xcf\xe1\xe8\xe6\xda\x39\xe7\x64\xcb\x55\xbe\x74\x59\x6d\x61\xec\x84\xda\xf8\x0f\xcd\x23\x52\xae\xc3\x0f\x51\xdd\xbf\xa8\x05\xd9\x7e\x8c\xdf\xa3\x79\xb1\x84\xe8\xd1\x86\x83\x7a\x4c\x91\xc4\x18\x33\xcd\x59\x52\x35\x32\x4e\x60\x54\x3f\x34\xde\xd1\xe7\x72\x8c\xc1\x0e\x05\x21\x10\xbf\x6a\x58\xf2\x04\x8b\xc3\x64\x33\x7b\xc4\x6c\x64\x8f\x98\x75\x7b\x65\x7f\xf9\x04\x9e\x38\x03\xeb\x3e\x15\xb5\x3d\x92\x42\x1b\x66\x8a\xaf\x38\x1c\xf1\x32\x8e\x46\x55\x98\x33\xbd\x7e\x25\x92\x93\xf9\xf8\x6f\x73\x37\x8c\x35\x67\x1d\xc0\x67\xae\xd7\x20\x72\xcc\x02\xf7\x7d\x97\x5a\x4a\xcb\x4b\x6f\x77\x9a\x91\x93\x03\x73\x28\xaf\x9f\x08\x16\x07\xcb\x7a\x36\xda\x78\x07\x6d\x3c\xb6\xe7\xc2\x32\xb6\x42\x72\x39\xa4\xc3\x07\xe6\x90\x8a\xdf\x83\x4e\xa7\xdb\x3b\x9e\xa9\x3c\xa7\xb0\xcb\xd8\xf5\x5a\x8d\xd8\x8d\xcf\xed\x70\x32\xfc\xcb\xf8\x76\x3c\x99\x87\xbf\xcc\xc6\xf7\xe1\x64\x78\x3b\xde\x15\xf5\x1a\xcf\xdd\x70\x36\xfb\x6d\x7a\xff\xd9\xe5\xeb\x5a\x00\x51\xde\x88\xcd\xee\xd3\xa4\x06\x93\x47\x0a\x17\x64\xa3\x77\xd1\x73\x50\x6d\x41\xd6\x58\x50\xe9\x6b\x5e\xcd\x4a\x76\xd4\xef\x6c\x6b\xc9\xa5\x7d\x5b\xdf\xc4\x36\x49\x2b\x2b\x61\x00\x6d\xc7\xf0\x76\xc9\xe8\xeb\x5c\x81\xe6\x52\x12\x95\x42\xb0\x7b\x1c\xb2\xc4\x9b\x39\x96\xd8\x9c\x76\x35\xa0\x1a\x62\xdd\x25\xb6\xfc\xd2\x53\x3f\x0b\x2a\x2f\x9a\x94\x15\xb0\xcd\x69\xc8\xa3\x14\x55\xdf\x96\x8d\x9e\x26\x54\x77\x4e\xb8\x38\x4a\x77\x16\xa3\x96\x58\xd6\x00\x6c\xa8\x6b\x69\xbd\x57\xe4\xad\xeb\x08\x75\x93\xcd\x54\x99\x19\x4e\x98\x98\x73\x50\x05\xce\x1a\x6d\xc5\x16\x65\xbb\x8d\xce\x23\x35\x35\xca\x33\xa3\xc8\x51\x40\xc1\xa5\x33\xa6\x47\x05\xb7\xac\x14\xca\x11\x2c\xee\x83\x96\x5a\xa2\x16\x9e\xab\x5d\x88\x98\x26\xaa\x39\xc2\x7a\x48\x69\xdd\x84\x6e\x99\x24\xe5\xa6\xc1\x52\xd8\x70\x1d\x56\x57\x8b\xdd\xf3\xa7\xf9\x15\xc2\x41\x66\xe3\xb9\xfb\xf9\xa1\xa1\xeb\x45\x1f\xf6\x94\xb5\x7c\xd8\xd8\x19\xc6\xf2\x77\xcc\x77\x90\x06\x53\x23\x33\x3a\x4a\x6d\xc6\x52\x11\xcb\x5e\x3c\x4c\xb6\x34\x08\xa2\xed\x40\xa7\x73\xb0\xc6\xe9\x4c\x04\x5c\xdf\x95\x67\x75\x68\x07\x48\x72\x61\xcd\x14\x2c\xe8\x40\x96\x3d\x6c\x18\xf7\x2d\xbe\xf8\xd2\x68\x3b\x42\x6a\xbd\x00\x18\x0e\x02\xf8\x8b\x62\x4b\xf3\xdf\x26\xba\xe2\x3a\x2c\xba\x46\x1a\x0c\x8e\x3c\x8e\x67\x18\xeb\x4f\x04\x2d\x78\xad\x5f\x5a\xa8\x3a\xb9\x05\x06\x50\xbf\xde\x5b\x0d\xab\x54\x68\x57\x78\x67\x64\xad\x1e\xe7\x49\x0d\x23\xc7\x80\x46\x22\xeb\x1d\x28\x05\x9d\x6c\x90\x4b\x9e\xe9\xc0\x2c\xfe\xf1\x26\xcd\x55\xe0\x28\xe8\xd2\x02\xc8\x97\x10\x9a\x55\x3d\x0c\x29\xfa\x7e\x18\xa6\x8c\x67\x61\x68\x77\x90\x14\xa2\x96\x92\xe5\xfa\x2e\x9c\x4f\xc3\xd9\x68\x38\x71\xf2\x4f\x33\x90\x30\xd8\xaf\xcc\x0c\x99\x76\x8f\x21\x1c\x46\xb2\xa1\x45\x73\x5a\x13\x82\x12\x02\xbd\xfa\x84\xe4\x48\xa4\xe9\x26\xe3\x91\xe9\x68\x9b\xad\x82\x29\x80\x9c\x8d\x44\xe1\x3b\xf6\x99\x5c\xa9\xc6\xd7\x6e\xb5\x55\x7d\xfc\xca\x75\x70\xd6\xf5\xfe\x77\x00\xaa\x34\xf1\xd1\xcb\x30\x00\x00")
 
 func iloPyBytes() ([]byte, error) {
 	return bindataRead(
@@ -126,7 +127,7 @@ func iloPy() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "ilo.py", size: 8613, mode: os.FileMode(420), modTime: time.Unix(1473167631, 0)}
+	info := bindataFileInfo{name: "ilo.py", size: 12491, mode: os.FileMode(420), modTime: time.Unix(1792278644, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -151,6 +152,26 @@ func iloToml() (*asset, error) {
 	return a, nil
 }
 
+var _scanResultV1SchemaJson = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xdc\x57\x4f\x73\xda\x3e\x10\xbd\xfb\x53\xec\xe8\x97\x43\x32\x63\xff\x80\x34\xed\xb4\x5c\x3a\xbd\x34\xa7\x7e\x02\x86\x7a\x84\x58\x40\x89\xfe\xcd\x4a\x0e\x93\x66\xfc\xdd\x3b\x0e\x8d\x11\xb5\x69\xe2\x90\xa4\xd4\xd2\x65\xbd\xe6\x3d\xa4\xf7\x56\x2b\xb8\x4b\x00\x00\xd8\x89\x17\x2b\xd4\x9c\x8d\x81\xad\x42\x70\xe3\xc1\xe0\xca\x5b\x93\x6d\xb2\xff\x5b\x5a\x0e\xe6\xc4\x17\x21\x1b\x5e\x0c\x36\xb9\xff\x58\xba\x41\x06\x19\x14\x56\x38\xe2\xca\xad\x32\xa1\x24\x78\xc1\x0d\x10\xfa\x42\x05\x38\xf5\x82\xa4\x0b\x1e\x84\x35\x81\xb8\x08\x29\xdc\x20\x79\x69\x0d\x8c\xce\x6a\x8e\x5b\x77\x4f\x61\x67\x57\x28\xc2\x43\xd6\x91\x75\x48\x41\xa2\x67\x63\xd8\xac\xb3\x9a\xec\x81\x29\xff\x45\x54\xbd\x7d\xa0\x98\x30\x69\x02\x2e\x91\x58\x0a\xcc\x14\x4a\xb1\x69\x0a\x4c\x4b\x23\x75\xa1\xd9\x18\x46\x65\xba\x25\xf2\x48\x92\xab\xdc\x14\x7a\x86\xb4\xcb\xe2\x03\x49\xb3\xdc\x92\xc4\x30\x6d\xe7\xa8\x72\xc3\x35\x3e\x1d\xb3\x90\xa4\xd7\x9c\xb0\x7d\xcd\x7f\x42\xce\xa4\xf5\xdd\x51\x18\x56\x48\x06\xc3\xae\x74\xb1\xd8\x13\xc6\x89\xf8\xed\x16\xbe\x45\x57\x93\xc9\x80\xba\x89\xde\x6f\x57\x3c\xf6\x59\x17\x0f\xa6\xb9\xd8\xfb\x72\x77\xa1\xbf\x6f\x34\x4d\xf6\x40\x80\x39\x1e\x02\x92\xa9\x8a\xe9\xfb\xe9\xe9\x64\x98\x7d\xfa\x92\x7d\xe5\xd9\x62\x7a\x77\x5e\x4e\xc6\xd9\xf4\xec\xee\x7d\xb9\x9b\x3d\xfb\x7c\xc2\x5a\xf9\x22\x39\xe3\xf9\x1c\xfb\xe3\xc1\xbc\x43\x9c\xbf\xfc\xce\xd1\xdc\x17\xf8\x84\x8d\x86\xf0\x6d\xe6\x7c\x25\xd7\x68\x18\xc5\x70\x59\x67\xeb\xf0\x62\x18\x65\xb7\x71\x61\xae\x8d\x5d\x1b\xd8\x2c\x35\x85\x4a\xf6\x69\x27\x91\x3a\xd6\x7b\x83\xa4\x4c\xda\x9f\xa2\xef\x63\x1a\xb5\xa5\xdb\x86\x92\xc7\x53\xe0\x87\x16\x8a\xfc\x81\x5d\x7a\xdb\xf0\xd1\x8a\x3b\x9c\x49\xd9\xf0\xc8\x66\x9e\x65\xe5\x42\xce\x08\x73\xb1\xe2\xc6\xa0\xca\x05\xa7\x79\x53\xd7\xfe\xf8\xfa\xda\x0d\x00\x2e\x67\x32\x54\x96\x9c\xd7\xd1\x45\x1d\x7d\xac\xa3\xd1\x87\x3a\x7c\xb7\xfd\xe4\xe1\x67\x7f\xbd\x7e\xfa\x45\x15\x8f\x37\x69\x1a\x8e\xac\x40\xef\x2d\x35\x0d\xee\x5f\x81\x1d\x7c\xde\x85\x25\xf4\x2f\xc2\xe4\xad\xb8\xc6\x57\xe9\x1d\x73\xe9\xaf\x9b\x62\xf6\xc7\xcc\x17\xbb\x05\x9e\xf5\x6b\xf7\x91\xf6\xdf\x58\xcb\x5f\x3d\xdb\x76\x8d\x94\xfb\xc2\x39\xd5\x66\x49\x6f\x4a\x62\xcd\x43\xe0\xcb\xa3\xa9\x8a\xb7\xb0\xd6\x07\x4b\x7c\x89\xf9\xfd\x7f\x3f\xab\x14\xf6\xb9\x7f\x77\x14\x74\x0f\x8b\xe0\x62\x85\x79\xf7\xf6\xd1\x60\x2b\x93\xf6\xa7\xd8\x9f\xa5\x2b\x9a\x82\xf4\xc6\x90\x7f\xe4\x90\x98\x1b\x8d\xf9\x9c\xe4\x4d\x8b\x16\xfd\xf1\xe2\x88\xee\xc3\x57\x33\x35\x01\x00\x28\x93\x32\xf9\x39\x00\x7c\x80\x1b\x62\x9f\x13\x00\x00")
+
+func scanResultV1SchemaJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_scanResultV1SchemaJson,
+		"scan_result.v1.schema.json",
+	)
+}
+
+func scanResultV1SchemaJson() (*asset, error) {
+	bytes, err := scanResultV1SchemaJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "scan_result.v1.schema.json", size: 5023, mode: os.FileMode(420), modTime: time.Unix(1792277499, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -207,6 +228,7 @@ var _bindata = map[string]func() (*asset, error){
 	"idrac.toml": idracToml,
 	"ilo.py": iloPy,
 	"ilo.toml": iloToml,
+	"scan_result.v1.schema.json": scanResultV1SchemaJson,
 }
 
 // AssetDir returns the file names below a certain
@@ -253,6 +275,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"idrac.toml": &bintree{idracToml, map[string]*bintree{}},
 	"ilo.py": &bintree{iloPy, map[string]*bintree{}},
 	"ilo.toml": &bintree{iloToml, map[string]*bintree{}},
+	"scan_result.v1.schema.json": &bintree{scanResultV1SchemaJson, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -39,6 +39,12 @@ SOAP_ENUM_WSMAN_TEMPLATE = '''<?xml version="1.0"?>
 </s:Envelope>
 '''
 
+# Version of the scripts contract (see ralph-cli docs) this script conforms to.
+CONTRACT_VERSION = 1
+ALL_COMPONENTS = [
+    'eth', 'mem', 'fcc', 'cpu', 'disk', 'psu', 'raid', 'gpu', 'nvme',
+]
+
 MAC_PREFIX_BLACKLIST = [
     '505054', '33506F', '009876', '000000', '00000C', '204153', '149120',
     '020054', 'FEFFFF', '1AF920', '020820', 'DEAD2C', 'FEAD4D',
@@ -55,6 +61,10 @@ DEVICE_INFO_TEMPLATE = {
     "fibre_channel_cards": [],
     "processors": [],
     "disks": [],
+    "power_supplies": [],
+    "storage_controllers": [],
+    "gpus": [],
+    "nvme_drives": [],
     "serial_number": "",
     "firmware_version": "",
     "bios_version": "",
@@ -69,11 +79,13 @@ PROCESSOR_TEMPLATE = {
     "model_name": "",
     "speed": None,
     "cores": None,
+    "socket": "",
 }
 MEMORY_TEMPLATE = {
     "model_name": "",
     "size": None,
     "speed": None,
+    "slot": "",
 }
 FIBRE_CHANNEL_CARD_TEMPLATE = {
     # firmware_version, speed and wwn are unused (iDRAC doesn't provide this info yet).
@@ -89,6 +101,30 @@ DISK_TEMPLATE = {
     "slot": None,
     "firmware_version": "",  # unused (iDRAC doesn't provide this info yet).
 }
+POWER_SUPPLY_TEMPLATE = {
+    "model_name": "",
+    "wattage": None,
+    "serial_number": "",
+    "firmware_version": "",
+}
+STORAGE_CONTROLLER_TEMPLATE = {
+    "model_name": "",
+    "firmware_version": "",
+    "cache_size": None,
+}
+GPU_TEMPLATE = {
+    # serial_number and firmware_version are unused (iDRAC doesn't provide
+    # this info yet).
+    "model_name": "",
+    "serial_number": "",
+    "firmware_version": "",
+}
+NVME_DRIVE_TEMPLATE = {
+    "model_name": "",
+    "size": None,
+    "serial_number": "",
+    "firmware_version": "",
+}
 
 
 def normalize_mac_address(mac_address):
@@ -308,6 +344,11 @@ def _get_processors(idrac_manager):
         processor['cores'] = int(record.find(
             "{}{}".format(xmlns_n1, 'NumberOfProcessorCores'),
         ).text.strip())
+        # e.g. "CPU.Socket.1"
+        fqdd = record.find("{}{}".format(xmlns_n1, 'FQDD'))
+        processor['socket'] = (
+            fqdd is not None and fqdd.text and fqdd.text.strip() or ''
+        )
         processors.append(processor)
     return processors
 
@@ -335,6 +376,11 @@ def _get_memory(idrac_manager):
         mem['speed'] = int(
             record.find("{}{}".format(xmlns_n1, 'Speed')).text.strip()
         )
+        # e.g. "DIMM.Socket.A1"
+        fqdd = record.find("{}{}".format(xmlns_n1, 'FQDD'))
+        mem['slot'] = (
+            fqdd is not None and fqdd.text and fqdd.text.strip() or ''
+        )
         memory.append(mem)
     return memory
 
@@ -402,13 +448,159 @@ def _get_fibre_channel_cards(idrac_manager):
     return fc_cards
 
 
-def idrac_device_info(idrac_manager):
+def _find_text(record, xmlns_n1, *names):
+    # Returns stripped text of the first of given elements which is present
+    # and not empty (the names of the elements vary between iDRAC versions),
+    # or an empty string.
+    for name in names:
+        el = record.find("{}{}".format(xmlns_n1, name))
+        if el is not None and el.text and el.text.strip():
+            return el.text.strip()
+    return ''
+
+
+def _get_power_supplies(idrac_manager):
+    tree = idrac_manager.run_command('DCIM_PowerSupplyView')
+    xmlns_n1 = XMLNS_N1_BASE % "DCIM_PowerSupplyView"
+    q = "{}Body/{}EnumerateResponse/{}Items/{}DCIM_PowerSupplyView".format(
+        XMLNS_S,
+        XMLNS_WSEN,
+        XMLNS_WSMAN,
+        xmlns_n1,
+    )
+    power_supplies = []
+    for record in tree.findall(q):
+        psu = deepcopy(POWER_SUPPLY_TEMPLATE)
+        psu['model_name'] = _find_text(record, xmlns_n1, 'Model')
+        # e.g. "495" (in watts)
+        wattage = _find_text(record, xmlns_n1, 'TotalOutputPower')
+        psu['wattage'] = int(wattage) if wattage else None
+        serial_number = _find_text(record, xmlns_n1, 'SerialNumber')
+        if serial_number not in SERIAL_BLACKLIST:
+            psu['serial_number'] = serial_number
+        psu['firmware_version'] = _find_text(
+            record, xmlns_n1, 'FirmwareVersion',
+        )
+        power_supplies.append(psu)
+    return power_supplies
+
+
+def _get_storage_controllers(idrac_manager):
+    tree = idrac_manager.run_command('DCIM_ControllerView')
+    xmlns_n1 = XMLNS_N1_BASE % "DCIM_ControllerView"
+    q = "{}Body/{}EnumerateResponse/{}Items/{}DCIM_ControllerView".format(
+        XMLNS_S,
+        XMLNS_WSEN,
+        XMLNS_WSMAN,
+        xmlns_n1,
+    )
+    controllers = []
+    for record in tree.findall(q):
+        controller = deepcopy(STORAGE_CONTROLLER_TEMPLATE)
+        controller['model_name'] = _find_text(record, xmlns_n1, 'ProductName')
+        controller['firmware_version'] = _find_text(
+            record, xmlns_n1, 'ControllerFirmwareVersion',
+        )
+        cache_size = _find_text(record, xmlns_n1, 'CacheSizeInMB')
+        controller['cache_size'] = int(cache_size) if cache_size else None
+        controllers.append(controller)
+    return controllers
+
+
+def _get_gpus(idrac_manager):
+    tree = idrac_manager.run_command('DCIM_VideoView')
+    xmlns_n1 = XMLNS_N1_BASE % "DCIM_VideoView"
+    q = "{}Body/{}EnumerateResponse/{}Items/{}DCIM_VideoView".format(
+        XMLNS_S,
+        XMLNS_WSEN,
+        XMLNS_WSMAN,
+        xmlns_n1,
+    )
+    gpus = []
+    for record in tree.findall(q):
+        # Skip the onboard video controller (e.g. "Video.Embedded.1-1").
+        fqdd = _find_text(record, xmlns_n1, 'FQDD')
+        if fqdd.startswith('Video.Embedded'):
+            continue
+        gpu = deepcopy(GPU_TEMPLATE)
+        gpu['model_name'] = _find_text(record, xmlns_n1, 'Description')
+        gpus.append(gpu)
+    return gpus
+
+
+def _get_nvme_drives(idrac_manager):
+    tree = idrac_manager.run_command('DCIM_PCIeSSDView')
+    xmlns_n1 = XMLNS_N1_BASE % "DCIM_PCIeSSDView"
+    q = "{}Body/{}EnumerateResponse/{}Items/{}DCIM_PCIeSSDView".format(
+        XMLNS_S,
+        XMLNS_WSEN,
+        XMLNS_WSMAN,
+        xmlns_n1,
+    )
+    drives = []
+    for record in tree.findall(q):
+        drive = deepcopy(NVME_DRIVE_TEMPLATE)
+        drive['model_name'] = _find_text(record, xmlns_n1, 'Model')
+        size_in_bytes = _find_text(record, xmlns_n1, 'SizeInBytes')
+        drive['size'] = int(int(size_in_bytes or 0) / 1024 / 1024 / 1024)
+        drive['serial_number'] = _find_text(record, xmlns_n1, 'SerialNumber')
+        drive['firmware_version'] = _find_text(
+            record, xmlns_n1, 'Revision', 'FirmwareVersion',
+        )
+        drives.append(drive)
+    return drives
+
+
+def requested_components():
+    # Older versions of ralph-cli don't tell which components are requested.
+    components = os.environ.get('RALPH_CLI_COMPONENTS')
+    if components is None:
+        return ALL_COMPONENTS
+    return [c for c in components.split(',') if c]
+
+
+def get_credentials():
+    # Credentials are passed in the context file (see Scripts Contract), or
+    # via environment, in case of older versions of ralph-cli.
+    context_path = os.environ.get('RALPH_CLI_CONTEXT')
+    if context_path:
+        with open(context_path) as f:
+            context = json.load(f)
+        return (
+            context.get('management_user_name', ""),
+            context.get('management_user_password', ""),
+        )
+    return (
+        os.environ.get('MANAGEMENT_USER_NAME', ""),
+        os.environ.get('MANAGEMENT_USER_PASSWORD', ""),
+    )
+
+
+def idrac_device_info(idrac_manager, components):
     device_info = _get_base_info(idrac_manager)
-    device_info['ethernets'] = _get_ethernets(idrac_manager)
-    device_info['processors'] = _get_processors(idrac_manager)
-    device_info['memory'] = _get_memory(idrac_manager)
-    device_info['disks'] = _get_disks(idrac_manager)
-    device_info['fibre_channel_cards'] = _get_fibre_channel_cards(idrac_manager)
+    device_info['contract_version'] = CONTRACT_VERSION
+    if 'eth' in components:
+        device_info['ethernets'] = _get_ethernets(idrac_manager)
+    if 'cpu' in components:
+        device_info['processors'] = _get_processors(idrac_manager)
+    if 'mem' in components:
+        device_info['memory'] = _get_memory(idrac_manager)
+    if 'disk' in components:
+        device_info['disks'] = _get_disks(idrac_manager)
+    if 'fcc' in components:
+        device_info['fibre_channel_cards'] = (
+            _get_fibre_channel_cards(idrac_manager)
+        )
+    if 'psu' in components:
+        device_info['power_supplies'] = _get_power_supplies(idrac_manager)
+    if 'raid' in components:
+        device_info['storage_controllers'] = (
+            _get_storage_controllers(idrac_manager)
+        )
+    if 'gpu' in components:
+        device_info['gpus'] = _get_gpus(idrac_manager)
+    if 'nvme' in components:
+        device_info['nvme_drives'] = _get_nvme_drives(idrac_manager)
     return device_info
 
 
@@ -420,14 +612,13 @@ def scan(host, user, password):
     if password == "":
         raise IdracError("No management password has been provided.")
     idrac_manager = IDRAC(host, user, password)
-    device_info = idrac_device_info(idrac_manager)
+    device_info = idrac_device_info(idrac_manager, requested_components())
     print(json.dumps(device_info))
 
 
 if __name__ == '__main__':
     host = os.environ.get('IP_TO_SCAN', "")
-    user = os.environ.get('MANAGEMENT_USER_NAME', "")
-    password = os.environ.get('MANAGEMENT_USER_PASSWORD', "")
+    user, password = get_credentials()
     try:
         scan(host, user, password)
     except IdracError as e:
@@ -8,20 +8,30 @@ from copy import deepcopy
 import hpilo
 
 
+# Version of the scripts contract (see ralph-cli docs) this script conforms to.
+CONTRACT_VERSION = 1
+# Components collected by this script (hpilo doesn't provide info about the
+# other ones).
+ALL_COMPONENTS = ['eth', 'mem', 'cpu', 'psu', 'raid']
+
 MAC_PREFIX_BLACKLIST = [
     '505054', '33506F', '009876', '000000', '00000C', '204153', '149120',
     '020054', 'FEFFFF', '1AF920', '020820', 'DEAD2C', 'FEAD4D',
 ]
 
 DEVICE_INFO_TEMPLATE = {
-    # fibre_channel_cards, disks, firmware_version and bios_version are unused
-    # (hpilo doesn't provide such info)
+    # fibre_channel_cards, disks, gpus, nvme_drives, firmware_version and
+    # bios_version are unused (hpilo doesn't provide such info)
     "model_name": "",
     "ethernets": [],
     "memory": [],
     "fibre_channel_cards": [],
     "processors": [],
     "disks": [],
+    "power_supplies": [],
+    "storage_controllers": [],
+    "gpus": [],
+    "nvme_drives": [],
     "serial_number": "",
     "firmware_version": "",
     "bios_version": "",
@@ -38,11 +48,24 @@ PROCESSOR_TEMPLATE = {
     "model_name": "",  # unused (hpilo doesn't provide such info)
     "speed": None,
     "cores": None,
+    "socket": "",
 }
 MEMORY_TEMPLATE = {
     "model_name": "",  # unused (hpilo doesn't provide such info)
     "size": None,
     "speed": None,
+    "slot": "",
+}
+POWER_SUPPLY_TEMPLATE = {
+    "model_name": "",
+    "wattage": None,
+    "serial_number": "",
+    "firmware_version": "",
+}
+STORAGE_CONTROLLER_TEMPLATE = {
+    "model_name": "",
+    "firmware_version": "",
+    "cache_size": None,
 }
 
 class IloError(Exception):
@@ -109,6 +132,8 @@ def _get_processors(raw_procs):
         proc = deepcopy(PROCESSOR_TEMPLATE)
         proc['speed'] = _get_speed(p.get('Speed'))
         proc['cores'] = get_cores(p.get('Execution Technology'))
+        # sample return value from hpilo: "Proc 1"
+        proc['socket'] = p.get('Label') or ''
         processors.append(proc)
     return processors
 
@@ -126,10 +151,59 @@ def _get_memory(raw_memory):
         mem = deepcopy(MEMORY_TEMPLATE)
         mem['size'] = get_size(m.get('Size'))
         mem['speed'] = _get_speed(m.get('Speed'))
+        # sample return value from hpilo: "PROC 1 DIMM 3" (iLO3/iLO4) or
+        # "DIMM 1A" (iLO2)
+        mem['slot'] = m.get('Label') or ''
         memory.append(mem)
     return memory
 
 
+def _get_power_supplies(raw_health):
+
+    def get_wattage(c):
+        # sample return value from hpilo: "460 Watts"
+        if c and c.split(" ")[0].isdigit():
+            return int(c.split(" ")[0])
+        return None
+
+    power_supplies = []
+    for p in raw_health.get('power_supplies', {}).values():
+        # Empty bays are reported too (e.g. "Not Installed").
+        if p.get('present', 'Yes') != 'Yes':
+            continue
+        psu = deepcopy(POWER_SUPPLY_TEMPLATE)
+        psu['model_name'] = (p.get('model') or '').strip()
+        psu['wattage'] = get_wattage(p.get('capacity'))
+        psu['serial_number'] = (p.get('serial_number') or '').strip()
+        psu['firmware_version'] = (p.get('firmware_version') or '').strip()
+        power_supplies.append(psu)
+    return power_supplies
+
+
+def _get_storage_controllers(raw_health):
+
+    def get_cache_size(c):
+        # sample return value from hpilo: "1048576 KB"
+        if c and c.split(" ")[0].isdigit():
+            return int(c.split(" ")[0]) // 1024
+        return None
+
+    controllers = []
+    # Reported by iLO4 only, as a dict of controllers keyed by their labels
+    # (e.g. "Controller on System Board").
+    for c in (raw_health.get('storage') or {}).values():
+        if not isinstance(c, dict):
+            continue
+        controller = deepcopy(STORAGE_CONTROLLER_TEMPLATE)
+        controller['model_name'] = (c.get('model') or '').strip()
+        controller['firmware_version'] = (c.get('fw_version') or '').strip()
+        controller['cache_size'] = get_cache_size(
+            c.get('cache_module_memory'),
+        )
+        controllers.append(controller)
+    return controllers
+
+
 # The data structure returned from python-hpilo is quite inconvenient for our
 # use-case, therefore we need to reshape it a little bit.
 def _prepare_host_data(raw_host_data, ilo_version):
@@ -219,21 +293,58 @@ def _prepare_host_data(raw_host_data, ilo_version):
     return host_data
 
 
-def ilo_device_info(ilo_manager, ilo_version):
+def requested_components():
+    # Older versions of ralph-cli don't tell which components are requested.
+    components = os.environ.get('RALPH_CLI_COMPONENTS')
+    if components is None:
+        return ALL_COMPONENTS
+    return [c for c in components.split(',') if c]
+
+
+def get_credentials():
+    # Credentials are passed in the context file (see Scripts Contract), or
+    # via environment, in case of older versions of ralph-cli.
+    context_path = os.environ.get('RALPH_CLI_CONTEXT')
+    if context_path:
+        with open(context_path) as f:
+            context = json.load(f)
+        return (
+            context.get('management_user_name', ""),
+            context.get('management_user_password', ""),
+        )
+    return (
+        os.environ.get('MANAGEMENT_USER_NAME', ""),
+        os.environ.get('MANAGEMENT_USER_PASSWORD', ""),
+    )
+
+
+def ilo_device_info(ilo_manager, ilo_version, components):
     raw_host_data = ilo_manager.get_host_data()
     host_data = _prepare_host_data(raw_host_data, ilo_version)
     device_info = DEVICE_INFO_TEMPLATE
-    device_info['processors'] = _get_processors(host_data['processors'])
-    device_info['ethernets'] = (
-        _get_ethernets(host_data['mac_addresses'], ilo_version)
-    )
+    device_info['contract_version'] = CONTRACT_VERSION
+    if 'cpu' in components:
+        device_info['processors'] = _get_processors(host_data['processors'])
+    if 'eth' in components:
+        device_info['ethernets'] = (
+            _get_ethernets(host_data['mac_addresses'], ilo_version)
+        )
     device_info['serial_number'] = (
         host_data['sys_info'][0].get('Serial Number', "").strip()
     )
     device_info['model_name'] = (
         host_data['sys_info'][0].get('Product Name', "")
     )
-    device_info['memory'] = _get_memory(host_data['memory'])
+    if 'mem' in components:
+        device_info['memory'] = _get_memory(host_data['memory'])
+    if 'psu' in components or 'raid' in components:
+        raw_health = ilo_manager.get_embedded_health()
+        if 'psu' in components:
+            device_info['power_supplies'] = _get_power_supplies(raw_health)
+        if 'raid' in components:
+            device_info['storage_controllers'] = (
+                _get_storage_controllers(raw_health)
+            )
     return device_info
 
 
@@ -247,14 +358,15 @@ def scan(host, user, password):
     ilo_manager = get_ilo_instance(host, user, password)
     fw_version = ilo_manager.get_fw_version()
     ilo_version = fw_version.get('management_processor')
-    device_info = ilo_device_info(ilo_manager, ilo_version)
+    device_info = ilo_device_info(
+        ilo_manager, ilo_version, requested_components()
+    )
     print(json.dumps(device_info))
 
 
 if __name__ == '__main__':
     host = os.environ.get('IP_TO_SCAN', "")
-    user = os.environ.get('MANAGEMENT_USER_NAME', "")
-    password = os.environ.get('MANAGEMENT_USER_PASSWORD', "")
+    user, password = get_credentials()
     try:
         scan(host, user, password)
     except (IloError, hpilo.IloCommunicationError) as e:
@@ -2,23 +2,34 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
+	"net"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
 // APIEndpoints maps ralph-cli types to Ralph's API endpoints.
 var APIEndpoints = map[string]string{
-	"BaseObject":       "base-objects",
-	"IPAddress":        "ipaddresses",
-	"Ethernet":         "ethernets",
-	"Memory":           "memory",
-	"FibreChannelCard": "fibre-channel-cards",
-	"Processor":        "processors",
-	"Disk":             "disks",
-	"DataCenterAsset":  "data-center-assets",
+	"BaseObject":        "base-objects",
+	"IPAddress":         "ipaddresses",
+	"Ethernet":          "ethernets",
+	"Memory":            "memory",
+	"FibreChannelCard":  "fibre-channel-cards",
+	"Processor":         "processors",
+	"Disk":              "disks",
+	"PowerSupply":       "power-supplies",
+	"StorageController": "storage-controllers",
+	"GPU":               "gpus",
+	"NVMeDrive":         "nvme-drives",
+	"DataCenterAsset":   "data-center-assets",
+	"Bulk":              "bulk",
 }
 
 // Client provides an interface to interact with Ralph via its REST API.
@@ -28,19 +39,37 @@ type Client struct {
 	apiKey      string
 	apiVersion  string // Not used b/c Ralph doesn't have any API versioning (yet).
 	client      *http.Client
+	maxRetries  int           // see Client.do
+	retryDelay  time.Duration // base delay for exponential backoff
+	limiter     *rateLimiter  // nil means no limit
 }
 
 // NewClient creates a new Client instance. If client arg is nil, then http.Client with some
-// sensible defaults (e.g., for Timeout) will be used.
+// sensible defaults (e.g., for Timeout) will be used. When the given client has no Timeout,
+// a copy of it with cfg.ClientTimeout is used instead. All the Clients created for the same
+// Ralph instance share the same rate limit (see Config.ClientRateLimit).
 func NewClient(cfg *Config, scannedAddr Addr, client *http.Client) (*Client, error) {
-	if client == nil {
-		client = &http.Client{Timeout: time.Duration(cfg.ClientTimeout) * time.Second}
+	timeout := time.Duration(cfg.ClientTimeout) * time.Second
+	switch {
+	case client == nil:
+		client = &http.Client{Timeout: timeout}
+	case client.Timeout == 0:
+		c := *client
+		c.Timeout = timeout
+		client = &c
+	}
+	apiKey, err := resolveSecretOnce(cfg.RalphAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving RalphAPIKey: %v", err)
 	}
 	return &Client{
 		scannedAddr: scannedAddr,
 		ralphURL:    cfg.RalphAPIURL,
-		apiKey:      cfg.RalphAPIKey,
+		apiKey:      apiKey,
 		client:      client,
+		maxRetries:  cfg.ClientMaxRetries,
+		retryDelay:  time.Duration(cfg.ClientRetryDelay) * time.Millisecond,
+		limiter:     getRateLimiter(cfg.RalphAPIURL, cfg.ClientRateLimit),
 	}, nil
 }
 
@@ -61,34 +90,38 @@ func (c *Client) NewRequest(method, urlStr string, body io.Reader) (*http.Reques
 // the actual HTTP status code, or a special value 0, which designates the case
 // when there was an error caused by anything else than HTTP status code > 299.
 func (c *Client) SendToRalph(method, endpoint string, data []byte) (statusCode int, err error) {
-	url := fmt.Sprintf("%s/%s/", c.ralphURL, endpoint)
-	var req *http.Request
-	switch {
-	case method == "DELETE":
-		req, err = c.NewRequest(method, url, nil)
-	default:
-		req, err = c.NewRequest(method, url, bytes.NewBuffer(data))
-		req.Header.Set("Content-Type", "application/json")
+	statusCode, _, err = c.sendToRalph(method, endpoint, data)
+	return statusCode, err
+}
+
+// endpointURL returns the URL of a given endpoint (e.g. "ethernets/5") of
+// Ralph's API, to which requests are sent.
+func (c *Client) endpointURL(endpoint string) string {
+	return fmt.Sprintf("%s/%s/", c.ralphURL, endpoint)
+}
+
+// sendToRalph works in the same way as SendToRalph, but it also returns the
+// body of Ralph's response (e.g. for getting IDs of newly created objects).
+func (c *Client) sendToRalph(method, endpoint string, data []byte) (statusCode int, body []byte, err error) {
+	url := c.endpointURL(endpoint)
+	if method == "DELETE" {
+		data = nil
 	}
+	resp, err := c.do(method, url, data)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
-
-	resp, err := c.client.Do(req)
+	defer resp.Body.Close()
+	body, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return 0, err
+		return 0, nil, fmt.Errorf("error reading response body: %v", err)
 	}
-	defer resp.Body.Close()
 	if resp.StatusCode >= 400 {
-		body, err := readBody(resp)
-		if err != nil {
-			return 0, err
-		}
 		err = fmt.Errorf("error while sending to %s with %s method: %s (%s)",
 			url, method, body, resp.Status)
-		return resp.StatusCode, err
+		return resp.StatusCode, body, err
 	}
-	return resp.StatusCode, nil
+	return resp.StatusCode, body, nil
 }
 
 // GetFromRalph sends a GET request on a given endpoint with specified query.
@@ -96,15 +129,68 @@ func (c *Client) GetFromRalph(endpoint string, query string) ([]byte, error) {
 	var url string
 	switch {
 	case query == "":
-		url = fmt.Sprintf("%s/%s/", c.ralphURL, endpoint)
+		url = c.endpointURL(endpoint)
 	default:
-		url = fmt.Sprintf("%s/%s/?%s", c.ralphURL, endpoint, query)
+		url = fmt.Sprintf("%s?%s", c.endpointURL(endpoint), query)
 	}
-	req, err := c.NewRequest("GET", url, nil)
-	if err != nil {
-		return []byte{}, err
+	return c.get(url)
+}
+
+// page represents a single page of results returned by Ralph for its list
+// endpoints (e.g. "ethernets").
+type page struct {
+	Count   int               `json:"count"`
+	Next    string            `json:"next"`
+	Results []json.RawMessage `json:"results"`
+}
+
+// GetAllFromRalph works like GetFromRalph, but it is meant for Ralph's list
+// endpoints, whose results are paginated. It fetches all the pages (by
+// following "next" links, or - when they are missing - by requesting
+// subsequent offsets), and returns all the results gathered from them as a
+// single JSON array.
+func (c *Client) GetAllFromRalph(endpoint string, query string) ([]byte, error) {
+	var url = c.endpointURL(endpoint)
+	var q = neturl.Values{}
+	if query != "" {
+		var err error
+		if q, err = neturl.ParseQuery(query); err != nil {
+			return nil, fmt.Errorf("error parsing query %q: %v", query, err)
+		}
+	}
+	var results = []json.RawMessage{}
+	next := url
+	if len(q) > 0 {
+		next = fmt.Sprintf("%s?%s", url, q.Encode())
+	}
+	for next != "" {
+		body, err := c.get(next)
+		if err != nil {
+			return nil, err
+		}
+		var p page
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("error unmarshaling results from %s: %v", next, err)
+		}
+		results = append(results, p.Results...)
+		switch {
+		case p.Next != "":
+			next = p.Next
+		case len(p.Results) > 0 && len(results) < p.Count:
+			q.Set("offset", strconv.Itoa(len(results)))
+			q.Set("limit", strconv.Itoa(len(p.Results)))
+			next = fmt.Sprintf("%s?%s", url, q.Encode())
+		default:
+			next = ""
+		}
 	}
-	resp, err := c.client.Do(req)
+	return json.Marshal(results)
+}
+
+// get is a helper method for GetFromRalph and GetAllFromRalph, which sends
+// a GET request to a given url.
+func (c *Client) get(url string) ([]byte, error) {
+	resp, err := c.do("GET", url, nil)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -120,10 +206,156 @@ func (c *Client) GetFromRalph(endpoint string, query string) ([]byte, error) {
 	return body, nil
 }
 
-func readBody(resp *http.Response) (string, error) {
-	body, err := ioutil.ReadAll(resp.Body)
+// Maximal delay between retries (see Client.do).
+const maxRetryDelay = 30 * time.Second
+
+// sleep is used by Client.do for waiting between retries (it's a variable to
+// facilitate testing).
+var sleep = time.Sleep
+
+// do sends a request with a given method to url, with data as its body (if
+// not nil). Failed requests are retried (up to c.maxRetries times) with
+// exponential backoff and jitter, or after the time given by Retry-After
+// header. Only requests that can be safely repeated are retried, i.e.:
+//   - GET, PATCH and DELETE requests - on connection errors and on 429, 502,
+//     503 and 504 status codes;
+//   - POST requests - only when the connection couldn't be established or
+//     when they were rejected with 429 status code (so they surely weren't
+//     processed by Ralph).
+func (c *Client) do(method, url string, data []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(data)
+		}
+		req, err := c.NewRequest(method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		c.limiter.Wait()
+		resp, err := c.client.Do(req)
+		if attempt >= c.maxRetries || !shouldRetry(method, resp, err) {
+			return resp, err
+		}
+		delay := c.backoff(attempt)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				delay = d
+			}
+			resp.Body.Close()
+		}
+		sleep(delay)
+	}
+}
+
+// shouldRetry is a helper function for Client.do, telling if a request with a
+// given method should be retried after getting resp and err.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := method == "GET" || method == "PATCH" || method == "DELETE"
 	if err != nil {
-		return "", fmt.Errorf("error reading response body: %v", err)
+		return idempotent || isDialError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// isDialError returns true if err occurred while establishing connection
+// (i.e. before anything was sent).
+func isDialError(err error) bool {
+	if uerr, ok := err.(*neturl.Error); ok {
+		err = uerr.Err
+	}
+	operr, ok := err.(*net.OpError)
+	return ok && operr.Op == "dial"
+}
+
+// backoff returns delay before the next retry of a request, which failed
+// given number of times, i.e. c.retryDelay * 2^attempt (but not more than
+// maxRetryDelay), randomized by the "equal jitter" method (half of it is
+// fixed, the other half is random).
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.retryDelay << uint(attempt)
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter returns the delay requested by Ralph in Retry-After header of
+// resp (given either in seconds or as an HTTP date).
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(h); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		d := t.Sub(time.Now())
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// rateLimiter limits the rate of requests sent to Ralph by spacing them
+// evenly in time. It is safe for concurrent use.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// Wait blocks until the next request can be sent. It's a no-op for nil
+// rateLimiter (i.e. when there's no limit).
+func (l *rateLimiter) Wait() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+	sleep(wait)
+}
+
+// Rate limiters shared by Clients (see getRateLimiter).
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = make(map[string]*rateLimiter)
+)
+
+// getRateLimiter returns rateLimiter allowing up to rate requests per second
+// to Ralph given as ralphURL. The same rateLimiter is returned for the same
+// arguments, so it can be shared by many Clients (e.g. when scanning many
+// hosts concurrently). If rate is not positive, nil is returned (no limit).
+func getRateLimiter(ralphURL string, rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s %g", ralphURL, rate)
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+	l, ok := rateLimiters[key]
+	if !ok {
+		l = &rateLimiter{interval: time.Duration(float64(time.Second) / rate)}
+		rateLimiters[key] = l
 	}
-	return string(body), nil
+	return l
 }
@@ -1,7 +1,15 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -16,10 +24,31 @@ func TestNewClient(t *testing.T) {
 		errMsg      string
 		want        *Client
 	}{
+		{
+			&Config{
+				RalphAPIURL:      "http://localhost:8080/api",
+				RalphAPIKey:      "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				ClientTimeout:    10,
+				ClientMaxRetries: 3,
+				ClientRetryDelay: 500,
+			},
+			Addr("10.20.30.40"),
+			"",
+			&Client{
+				"10.20.30.40",
+				"http://localhost:8080/api",
+				"abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				"", // apiVersion
+				&http.Client{Timeout: time.Second * 10},
+				3,
+				time.Millisecond * 500,
+				nil, // limiter
+			},
+		},
 		{
 			&Config{
 				RalphAPIURL:   "http://localhost:8080/api",
-				RalphAPIKey:   "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				RalphAPIKey:   "env:RALPH_CLI_TEST_API_KEY",
 				ClientTimeout: 10,
 			},
 			Addr("10.20.30.40"),
@@ -27,20 +56,77 @@ func TestNewClient(t *testing.T) {
 			&Client{
 				"10.20.30.40",
 				"http://localhost:8080/api",
-				"abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				"api_key_from_env",
 				"", // apiVersion
 				&http.Client{Timeout: time.Second * 10},
+				0,
+				0,
+				nil, // limiter
 			},
 		},
+		{
+			&Config{
+				RalphAPIURL: "http://localhost:8080/api",
+				RalphAPIKey: "env:RALPH_CLI_TEST_DOES_NOT_EXIST",
+			},
+			Addr("10.20.30.40"),
+			"error resolving RalphAPIKey",
+			nil,
+		},
 	}
+	os.Setenv("RALPH_CLI_TEST_API_KEY", "api_key_from_env")
+	defer os.Unsetenv("RALPH_CLI_TEST_API_KEY")
+
 	for tn, tc := range cases {
 		got, err := NewClient(tc.config, tc.scannedAddr, nil)
-		if err != nil {
-			t.Fatalf("err: %s", err)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("#%d\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+				t.Errorf("#%d\n%s", tn, err)
+			}
 		}
-		if eq, err := checkers.DeepEqual(got, tc.want); !eq {
-			t.Errorf("#%d\n%s", tn, err)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(3 * time.Second):
+		case <-r.Context().Done():
 		}
+	}))
+	defer server.Close()
+
+	// The same as with --timeout=1 (see main).
+	flags := map[string]string{
+		"ClientTimeout":    "1",
+		"ClientMaxRetries": "-1",
+		"RalphAPIURL":      server.URL,
+	}
+	cfg, err := GetConfig(filepath.Join(configTestFixturesDir, "config.toml"), "", flags)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	// PerformScan gives its own http.Client, without any Timeout.
+	client, err := NewClient(cfg, Addr("10.20.30.40"), &http.Client{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	start := time.Now()
+	_, err = client.GetFromRalph("memory", "")
+	if err == nil {
+		t.Fatalf("expected timeout error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("request timed out after %s, want ~1s", elapsed)
 	}
 }
 
@@ -129,3 +215,281 @@ func TestGetFromRalph(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAllFromRalph(t *testing.T) {
+	var results = []string{`{"id":1}`, `{"id":2}`, `{"id":3}`, `{"id":4}`, `{"id":5}`}
+	var cases = map[string]struct {
+		withNext bool // if false, pages don't have "next" links
+		pageSize int
+		failAt   int // offset at which Ralph responds with 500 (0 means never)
+		errMsg   string
+		want     string
+		wantURLs []string
+	}{
+		"#0 Single page": {
+			withNext: true,
+			pageSize: 10,
+			want:     `[{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}]`,
+			wantURLs: []string{"/ethernets/?base_object=1"},
+		},
+		"#1 Following next links": {
+			withNext: true,
+			pageSize: 2,
+			want:     `[{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}]`,
+			wantURLs: []string{
+				"/ethernets/?base_object=1",
+				"/ethernets/?base_object=1&limit=2&offset=2",
+				"/ethernets/?base_object=1&limit=2&offset=4",
+			},
+		},
+		"#2 Using offset and limit": {
+			withNext: false,
+			pageSize: 2,
+			want:     `[{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}]`,
+			wantURLs: []string{
+				"/ethernets/?base_object=1",
+				"/ethernets/?base_object=1&limit=2&offset=2",
+				"/ethernets/?base_object=1&limit=2&offset=4",
+			},
+		},
+		"#3 Error on subsequent page": {
+			withNext: true,
+			pageSize: 2,
+			failAt:   2,
+			errMsg:   "error while sending a GET request to Ralph",
+			wantURLs: []string{
+				"/ethernets/?base_object=1",
+				"/ethernets/?base_object=1&limit=2&offset=2",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		var gotURLs []string
+		tc := tc
+		var server *httptest.Server
+		server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotURLs = append(gotURLs, r.URL.RequestURI())
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			if tc.failAt != 0 && offset == tc.failAt {
+				w.WriteHeader(500)
+				return
+			}
+			end := offset + tc.pageSize
+			if end > len(results) {
+				end = len(results)
+			}
+			var next = "null"
+			if tc.withNext && end < len(results) {
+				next = fmt.Sprintf(`"%s/ethernets/?base_object=1&limit=%d&offset=%d"`, server.URL, tc.pageSize, end)
+			}
+			fmt.Fprintf(w, `{"count": %d, "next": %s, "results": [%s]}`,
+				len(results), next, strings.Join(results[offset:end], ","))
+		}))
+
+		got, err := client.GetAllFromRalph("ethernets", "base_object=1")
+		server.Close()
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("%s\n got: %s\nwant: %s", tn, got, tc.want)
+			}
+		}
+		if eq, err := checkers.DeepEqual(gotURLs, tc.wantURLs); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var cases = map[string]struct {
+		method       string
+		codes        []int // status codes returned by subsequent responses
+		maxRetries   int
+		wantAttempts int
+		wantCode     int
+	}{
+		"#0 No retries needed": {
+			"GET",
+			[]int{200},
+			3,
+			1,
+			200,
+		},
+		"#1 GET retried on 502 and 503": {
+			"GET",
+			[]int{502, 503, 200},
+			3,
+			3,
+			200,
+		},
+		"#2 Retries exhausted": {
+			"PATCH",
+			[]int{504, 504, 504},
+			2,
+			3,
+			504,
+		},
+		"#3 POST not retried on 502": {
+			"POST",
+			[]int{502, 201},
+			3,
+			1,
+			502,
+		},
+		"#4 POST retried on 429": {
+			"POST",
+			[]int{429, 201},
+			3,
+			2,
+			201,
+		},
+		"#5 Retries disabled": {
+			"DELETE",
+			[]int{503, 204},
+			-1,
+			1,
+			503,
+		},
+		"#6 No retries on 500": {
+			"DELETE",
+			[]int{500, 204},
+			3,
+			1,
+			500,
+		},
+	}
+
+	defer func() { sleep = time.Sleep }()
+	for tn, tc := range cases {
+		var delays []time.Duration
+		sleep = func(d time.Duration) { delays = append(delays, d) }
+		var attempts int
+		codes := tc.codes
+		server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(codes[attempts])
+			attempts++
+		}))
+		client.maxRetries = tc.maxRetries
+		client.retryDelay = 100 * time.Millisecond
+
+		got, _ := client.SendToRalph(tc.method, "memory", []byte(`{}`))
+		server.Close()
+		if attempts != tc.wantAttempts {
+			t.Errorf("%s\n got attempts: %d\nwant attempts: %d", tn, attempts, tc.wantAttempts)
+		}
+		if got != tc.wantCode {
+			t.Errorf("%s\n got: %d\nwant: %d", tn, got, tc.wantCode)
+		}
+		// Exponential backoff with "equal jitter": 2nd delay is between 100
+		// and 200 ms etc.
+		for i, d := range delays {
+			max := client.retryDelay << uint(i)
+			if d < max/2 || d > max {
+				t.Errorf("%s\ndelay #%d out of range: %s", tn, i, d)
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var cases = map[string]struct {
+		header string
+		want   time.Duration
+		wantOk bool
+	}{
+		"#0 No header":        {"", 0, false},
+		"#1 Seconds":          {"120", 120 * time.Second, true},
+		"#2 Date in the past": {"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		"#3 Invalid value":    {"soon", 0, false},
+	}
+
+	for tn, tc := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		if tc.header != "" {
+			resp.Header.Set("Retry-After", tc.header)
+		}
+		got, ok := retryAfter(resp)
+		if got != tc.want || ok != tc.wantOk {
+			t.Errorf("%s\n got: %s, %v\nwant: %s, %v", tn, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	defer func() { sleep = time.Sleep }()
+	var delays []time.Duration
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	var attempts int
+	server, client := MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(429)
+			return
+		}
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer server.Close()
+	client.maxRetries = 3
+
+	if _, err := client.GetFromRalph("memory", ""); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	want := []time.Duration{7 * time.Second}
+	if eq, err := checkers.DeepEqual(delays, want); !eq {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestShouldRetryOnConnectionErrors(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "http://ralph", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	readErr := &url.Error{Op: "Post", URL: "http://ralph", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}
+	var cases = map[string]struct {
+		method string
+		err    error
+		want   bool
+	}{
+		"#0 GET on dial error":  {"GET", dialErr, true},
+		"#1 GET on read error":  {"GET", readErr, true},
+		"#2 POST on dial error": {"POST", dialErr, true},
+		"#3 POST on read error": {"POST", readErr, false},
+	}
+
+	for tn, tc := range cases {
+		got := shouldRetry(tc.method, nil, tc.err)
+		if got != tc.want {
+			t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	defer func() { sleep = time.Sleep }()
+	var total time.Duration
+	sleep = func(d time.Duration) { total += d }
+
+	l := getRateLimiter("http://localhost:8080/api", 10)
+	if l2 := getRateLimiter("http://localhost:8080/api", 10); l2 != l {
+		t.Errorf("rate limiter should be shared for the same Ralph instance")
+	}
+	if l3 := getRateLimiter("http://localhost:8080/api", 0); l3 != nil {
+		t.Errorf("rate limiter should be nil when there's no limit, got: %+v", l3)
+	}
+	for i := 0; i < 5; i++ {
+		l.Wait()
+	}
+	// Requests are spaced by 100 ms, and since sleep is mocked here, they wait
+	// for 0, 100, 200, 300 and 400 ms respectively (we allow some slack, since
+	// time passes between calls to Wait).
+	if total < 900*time.Millisecond || total > time.Second {
+		t.Errorf("total wait time out of range: %s", total)
+	}
+}
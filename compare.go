@@ -0,0 +1,398 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// componentDescriptor describes how components of a given type (e.g. Disk) are
+// compared - both for equality (see IsEqualTo) and when looking for changes
+// between components stored in Ralph and the ones detected on a host (see
+// compareComponents). Fields are given by their names, or as dot-separated
+// paths for nested ones (e.g. "BaseObject.ID"). Each field of the component
+// should be listed in one of Compare, CompareIfSet or Ignore, so adding a new
+// field without deciding how it should be compared can be caught by tests.
+type componentDescriptor struct {
+	// Identity lists fields identifying a single component (e.g. MAC address
+	// of Ethernet). Components with the same identity are treated as the same
+	// component, which gets updated when any of the Compare fields differs.
+	// When empty, components are not unique - they are compared as multisets
+	// (see Matching).
+	Identity []string
+	// Matching lists best-effort identities of components which are not
+	// unique (e.g. serial number or slot of Disk), in the order they should
+	// be tried. Components with the same values of all the fields of one of
+	// these identities are treated as the same component, which gets updated
+	// when it has changed. Fields without a value (see fieldIsSet) never
+	// match. Changed components which can't be matched in this way are
+	// deleted and created anew.
+	Matching [][]string
+	// Compare lists fields taken into account when comparing components.
+	Compare []string
+	// CompareIfSet lists fields taken into account only when they are set
+	// (see fieldIsSet) in both compared components - e.g. the ones which
+	// are not stored by Ralph yet, so their values read from Ralph are always
+	// empty, which shouldn't be treated as a change.
+	CompareIfSet []string
+	// Ignore lists fields which are not taken into account (e.g. ID).
+	Ignore []string
+}
+
+var ethernetDescriptor = componentDescriptor{
+	Identity: []string{"MACAddress"},
+	Compare:  []string{"BaseObject.ID", "MACAddress", "ModelName", "Speed", "FirmwareVersion"},
+	Ignore:   []string{"ID"},
+}
+
+// Slot and Socket are optional, and Ralph doesn't store them (yet), so they are
+// compared only when given on both sides. Memory and Processors without them
+// can't be matched in any other way (matching them by all the other fields
+// would give the same pairs as comparing them for equality).
+var memoryDescriptor = componentDescriptor{
+	Matching:     [][]string{{"Slot"}},
+	Compare:      []string{"BaseObject.ID", "ModelName", "Size", "Speed"},
+	CompareIfSet: []string{"Slot"},
+	Ignore:       []string{"ID"},
+}
+
+// At first, it may seem that FibreChannelCards can be compared as unique
+// objects, thanks to WWN, but unfortunately, this field will be empty quite
+// often, so we have to compare them in the same way as Memory (while WWN is
+// still used for matching them when it's given).
+var fibreChannelCardDescriptor = componentDescriptor{
+	Matching: [][]string{{"WWN"}},
+	Compare:  []string{"BaseObject.ID", "ModelName", "Speed", "WWN", "FirmwareVersion"},
+	Ignore:   []string{"ID"},
+}
+
+var processorDescriptor = componentDescriptor{
+	Matching:     [][]string{{"Socket"}},
+	Compare:      []string{"BaseObject.ID", "ModelName", "Speed", "Cores"},
+	CompareIfSet: []string{"Socket"},
+	Ignore:       []string{"ID"},
+}
+
+var diskDescriptor = componentDescriptor{
+	Matching: [][]string{{"SerialNumber"}, {"Slot"}},
+	Compare:  []string{"BaseObject.ID", "ModelName", "Size", "SerialNumber", "Slot", "FirmwareVersion"},
+	Ignore:   []string{"ID"},
+}
+
+var powerSupplyDescriptor = componentDescriptor{
+	Matching: [][]string{{"SerialNumber"}},
+	Compare:  []string{"BaseObject.ID", "ModelName", "Wattage", "SerialNumber", "FirmwareVersion"},
+	Ignore:   []string{"ID"},
+}
+
+// Storage controllers have no serial numbers (at least, not in the output of
+// bundled scripts), but there's rarely more than one controller of a given
+// model on a single host, so they are matched by their models.
+var storageControllerDescriptor = componentDescriptor{
+	Matching: [][]string{{"BaseObject.ID", "ModelName"}},
+	Compare:  []string{"BaseObject.ID", "ModelName", "FirmwareVersion", "CacheSize"},
+	Ignore:   []string{"ID"},
+}
+
+var gpuDescriptor = componentDescriptor{
+	Matching: [][]string{{"SerialNumber"}},
+	Compare:  []string{"BaseObject.ID", "ModelName", "SerialNumber", "FirmwareVersion"},
+	Ignore:   []string{"ID"},
+}
+
+var nvmeDriveDescriptor = componentDescriptor{
+	Matching: [][]string{{"SerialNumber"}},
+	Compare:  []string{"BaseObject.ID", "ModelName", "Size", "SerialNumber", "FirmwareVersion"},
+	Ignore:   []string{"ID"},
+}
+
+// isEqual compares components a and b (which may be given both as objects or
+// pointers) for equality, taking into account only d.Compare fields (and
+// d.CompareIfSet ones, when they are set in both a and b). Components of
+// different types are never equal.
+func (d componentDescriptor) isEqual(a, b Component) bool {
+	va := reflect.Indirect(reflect.ValueOf(a))
+	vb := reflect.Indirect(reflect.ValueOf(b))
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return false
+	}
+	if fieldsKey(va, d.Compare) != fieldsKey(vb, d.Compare) {
+		return false
+	}
+	return !identitiesConflict(a, b, [][]string{d.CompareIfSet})
+}
+
+// componentKey returns a string built from values of given fields of component
+// c, which is used for grouping components with the same values of these
+// fields.
+func componentKey(c Component, fields []string) string {
+	return fieldsKey(reflect.Indirect(reflect.ValueOf(c)), fields)
+}
+
+// fieldsKey returns values of given fields of struct v joined into a single
+// string. Values implementing fmt.Stringer (e.g. MACAddress) are represented by
+// their String(), so they are compared in their normalized form.
+func fieldsKey(v reflect.Value, fields []string) string {
+	var vals []string
+	for _, f := range fields {
+		fv := fieldByPath(v, f)
+		switch {
+		case fv.Kind() == reflect.String:
+			vals = append(vals, strconv.Quote(fv.String()))
+		default:
+			vals = append(vals, fmt.Sprint(fv.Interface()))
+		}
+	}
+	return strings.Join(vals, "__")
+}
+
+// fieldByPath returns field of struct v given as a dot-separated path (e.g.
+// "BaseObject.ID"). It panics when there's no such field, since it means that
+// a componentDescriptor is broken.
+func fieldByPath(v reflect.Value, path string) reflect.Value {
+	field := reflect.Indirect(v)
+	for _, name := range strings.Split(path, ".") {
+		field = reflect.Indirect(field).FieldByName(name)
+		if !field.IsValid() {
+			panic(fmt.Sprintf("no field %s in %s", path, reflect.Indirect(v).Type()))
+		}
+	}
+	return field
+}
+
+// toComponents converts a slice of pointers to components (e.g. []*Disk) into
+// []Component.
+func toComponents(slice interface{}) []Component {
+	v := reflect.ValueOf(slice)
+	components := make([]Component, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		components = append(components, v.Index(i).Interface().(Component))
+	}
+	return components
+}
+
+// compareComponents compares two sets of components of the same type (old and
+// new), as described by d, and creates a Diff holding detected changes.
+func compareComponents(d componentDescriptor, old, new []Component) (*Diff, error) {
+	if len(d.Identity) > 0 {
+		return compareUniqueComponents(d, old, new)
+	}
+	return compareComponentMultisets(d, old, new)
+}
+
+// compareUniqueComponents compares components which can be told apart by their
+// identity (see componentDescriptor.Identity). Components present only in new
+// are created, the ones present only in old are deleted, and the ones present
+// in both of them are updated (if they differ).
+func compareUniqueComponents(d componentDescriptor, old, new []Component) (*Diff, error) {
+	var create, update, delete []*DiffComponent
+	oldByKey := make(map[string]Component)
+	newKeys := make(map[string]bool)
+	for _, c := range old {
+		k := componentKey(c, d.Identity)
+		if _, ok := oldByKey[k]; !ok {
+			oldByKey[k] = c
+		}
+	}
+	for _, c := range new {
+		newKeys[componentKey(c, d.Identity)] = true
+	}
+
+	for _, c := range old {
+		if !newKeys[componentKey(c, d.Identity)] {
+			dc, err := NewDiffComponent(c)
+			if err != nil {
+				return nil, err
+			}
+			delete = append(delete, dc)
+		}
+	}
+	for _, c := range new {
+		cOld, ok := oldByKey[componentKey(c, d.Identity)]
+		if !ok {
+			dc, err := NewDiffComponent(c)
+			if err != nil {
+				return nil, err
+			}
+			create = append(create, dc)
+			continue
+		}
+		id := fieldByPath(reflect.ValueOf(c), "ID")
+		if id.Int() != 0 {
+			continue
+		}
+		// We need to assign the ID of matching component from old to the new
+		// one to make an update.
+		id.SetInt(fieldByPath(reflect.ValueOf(cOld), "ID").Int())
+		if id.Int() == 0 {
+			// This really shouldn't happen.
+			return nil, fmt.Errorf("error while preparing %s for update", c)
+		}
+		// Exclude components equal to their old versions (no need to make an
+		// update in such case).
+		if !d.isEqual(c, cOld) {
+			dc, err := NewDiffComponent(c)
+			if err != nil {
+				return nil, err
+			}
+			dc.Old = cOld
+			update = append(update, dc)
+		}
+	}
+	return &Diff{
+		Create: create,
+		Delete: delete,
+		Update: update,
+	}, nil
+}
+
+// compareComponentMultisets compares components which are not unique (i.e.,
+// w/o considering their IDs). At first, components equal to each other (i.e.,
+// according to d.isEqual) are paired, since they don't need any changes. Then,
+// the remaining ones are paired by each of d.Matching identities in turn, and
+// such pairs are updated (which preserves IDs of components in Ralph, along
+// with their history). Components left without a pair are created (when
+// present only in new) or deleted (when present only in old).
+func compareComponentMultisets(d componentDescriptor, old, new []Component) (*Diff, error) {
+	var create, delete []*DiffComponent
+	update := []*DiffComponent{}
+
+	if len(new) == 0 {
+		for _, c := range old {
+			dc, err := NewDiffComponent(c)
+			if err != nil {
+				return nil, err
+			}
+			delete = append(delete, dc)
+		}
+		return &Diff{
+			Create: []*DiffComponent{},
+			Delete: delete,
+			Update: update,
+		}, nil
+	}
+
+	// pairs[i] is the index of a component from old paired with new[i] (or -1).
+	pairs := make([]int, len(new))
+	paired := make([]bool, len(old))
+	for i := range pairs {
+		pairs[i] = -1
+	}
+	pair := func(matches func(o, n Component) bool, lastFirst bool) []int {
+		var justPaired []int
+		for i, n := range new {
+			if pairs[i] != -1 {
+				continue
+			}
+			for k := range old {
+				j := k
+				if lastFirst {
+					j = len(old) - 1 - k
+				}
+				if o := old[j]; !paired[j] && matches(o, n) {
+					pairs[i] = j
+					paired[j] = true
+					justPaired = append(justPaired, i)
+					break
+				}
+			}
+		}
+		return justPaired
+	}
+
+	// When there are more equal components in old than in new, the first
+	// ones are left for deletion.
+	pair(d.isEqual, true)
+	for m, identity := range d.Matching {
+		// Components paired by the previous identities are taken into account
+		// here, so e.g. disks with different serial numbers are never paired
+		// by their slots (since it means that the disk has been replaced).
+		previous := d.Matching[:m]
+		for _, i := range pair(func(o, n Component) bool {
+			return identityMatches(o, n, identity) && !identitiesConflict(o, n, previous)
+		}, false) {
+			cOld := old[pairs[i]]
+			fieldByPath(reflect.ValueOf(new[i]), "ID").SetInt(fieldByPath(reflect.ValueOf(cOld), "ID").Int())
+			dc, err := NewDiffComponent(new[i])
+			if err != nil {
+				return nil, err
+			}
+			dc.Old = cOld
+			update = append(update, dc)
+		}
+	}
+
+	for i, c := range new {
+		if pairs[i] == -1 {
+			dc, err := NewDiffComponent(c)
+			if err != nil {
+				return nil, err
+			}
+			create = append(create, dc)
+		}
+	}
+	for j, c := range old {
+		if !paired[j] {
+			dc, err := NewDiffComponent(c)
+			if err != nil {
+				return nil, err
+			}
+			delete = append(delete, dc)
+		}
+	}
+
+	return &Diff{
+		Create: create,
+		Delete: delete,
+		Update: update,
+	}, nil
+}
+
+// optionalField is implemented by types of component fields which have a
+// special value meaning "not given" (other than their zero value, e.g.
+// DiskSlotNumber).
+type optionalField interface {
+	isSet() bool
+}
+
+// fieldIsSet returns true if field v holds some value, i.e. it's not a zero
+// value of its type (or "not given" value of optionalField).
+func fieldIsSet(v reflect.Value) bool {
+	if f, ok := v.Interface().(optionalField); ok {
+		return f.isSet()
+	}
+	return !reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// identityMatches returns true if all the identity fields are given in both
+// a and b, and they are equal.
+func identityMatches(a, b Component, identity []string) bool {
+	va := reflect.Indirect(reflect.ValueOf(a))
+	vb := reflect.Indirect(reflect.ValueOf(b))
+	for _, f := range identity {
+		if !fieldIsSet(fieldByPath(va, f)) || !fieldIsSet(fieldByPath(vb, f)) {
+			return false
+		}
+	}
+	return fieldsKey(va, identity) == fieldsKey(vb, identity)
+}
+
+// identitiesConflict returns true if a and b have different values of any of
+// the given identities (which are given in both of them).
+func identitiesConflict(a, b Component, identities [][]string) bool {
+	va := reflect.Indirect(reflect.ValueOf(a))
+	vb := reflect.Indirect(reflect.ValueOf(b))
+	for _, identity := range identities {
+		set := true
+		for _, f := range identity {
+			if !fieldIsSet(fieldByPath(va, f)) || !fieldIsSet(fieldByPath(vb, f)) {
+				set = false
+			}
+		}
+		if set && fieldsKey(va, identity) != fieldsKey(vb, identity) {
+			return true
+		}
+	}
+	return false
+}
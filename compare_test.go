@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/juju/testing/checkers"
+)
+
+func TestComponentDescriptors(t *testing.T) {
+	var cases = map[string]struct {
+		component  interface{}
+		descriptor componentDescriptor
+	}{
+		"#0 Ethernet":          {Ethernet{}, ethernetDescriptor},
+		"#1 Memory":            {Memory{}, memoryDescriptor},
+		"#2 FibreChannelCard":  {FibreChannelCard{}, fibreChannelCardDescriptor},
+		"#3 Processor":         {Processor{}, processorDescriptor},
+		"#4 Disk":              {Disk{}, diskDescriptor},
+		"#5 PowerSupply":       {PowerSupply{}, powerSupplyDescriptor},
+		"#6 StorageController": {StorageController{}, storageControllerDescriptor},
+		"#7 GPU":               {GPU{}, gpuDescriptor},
+		"#8 NVMeDrive":         {NVMeDrive{}, nvmeDriveDescriptor},
+	}
+
+	for tn, tc := range cases {
+		listed := make(map[string]int)
+		fields := append(append([]string{}, tc.descriptor.Compare...), tc.descriptor.CompareIfSet...)
+		for _, f := range append(fields, tc.descriptor.Ignore...) {
+			// Panics when there's no such field.
+			fieldByPath(reflect.ValueOf(tc.component), f)
+			listed[strings.Split(f, ".")[0]]++
+		}
+		for _, f := range tc.descriptor.Identity {
+			fieldByPath(reflect.ValueOf(tc.component), f)
+		}
+		typ := reflect.TypeOf(tc.component)
+		for i := 0; i < typ.NumField(); i++ {
+			name := typ.Field(i).Name
+			switch listed[name] {
+			case 0:
+				t.Errorf("%s\nfield %s is neither compared nor ignored", tn, name)
+			case 1:
+			default:
+				t.Errorf("%s\nfield %s is listed %d times", tn, name, listed[name])
+			}
+		}
+	}
+}
+
+func TestCompareComponentMultisets(t *testing.T) {
+	var cases = map[string]struct {
+		old        []*Processor
+		new        []*Processor
+		wantCreate []int // indexes of created components in new
+		wantDelete []int // indexes of deleted components in old
+	}{
+		"#0 Changes are ordered as components": {
+			old: []*Processor{
+				&Processor{1, BaseObject{1}, "Intel Xeon E5", 2600, 8, ""},
+				&Processor{2, BaseObject{1}, "Intel Xeon E7", 2600, 8, ""},
+				&Processor{3, BaseObject{1}, "Intel Xeon E7", 2600, 8, ""},
+			},
+			new: []*Processor{
+				&Processor{0, BaseObject{1}, "Intel Xeon Gold", 2600, 16, ""},
+				&Processor{0, BaseObject{1}, "Intel Xeon E5", 2600, 8, ""},
+				&Processor{0, BaseObject{1}, "AMD EPYC", 2000, 32, ""},
+			},
+			wantCreate: []int{0, 2},
+			wantDelete: []int{1, 2},
+		},
+		"#1 Strings containing separators don't collide": {
+			old: []*Processor{
+				&Processor{1, BaseObject{1}, "Intel Xeon", 2600, 8, ""},
+			},
+			new: []*Processor{
+				&Processor{0, BaseObject{1}, "Intel_Xeon", 2600, 8, ""},
+			},
+			wantCreate: []int{0},
+			wantDelete: []int{0},
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := compareComponents(processorDescriptor, toComponents(tc.old), toComponents(tc.new))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		var gotCreate, wantCreate, gotDelete, wantDelete []Component
+		for _, d := range got.Create {
+			gotCreate = append(gotCreate, d.Component)
+		}
+		for _, i := range tc.wantCreate {
+			wantCreate = append(wantCreate, tc.new[i])
+		}
+		for _, d := range got.Delete {
+			gotDelete = append(gotDelete, d.Component)
+		}
+		for _, i := range tc.wantDelete {
+			wantDelete = append(wantDelete, tc.old[i])
+		}
+		if eq, err := checkers.DeepEqual(gotCreate, wantCreate); !eq {
+			t.Errorf("%s (create)\n%s", tn, err)
+		}
+		if eq, err := checkers.DeepEqual(gotDelete, wantDelete); !eq {
+			t.Errorf("%s (delete)\n%s", tn, err)
+		}
+	}
+}
@@ -2,38 +2,80 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 )
 
 // Config holds the configuration for ralph-cli.
 type Config struct {
-	Path                   string `toml:"-"`
-	LogOutput              string `toml:"-"`
-	ClientTimeout          int    `toml:"-"`
+	Path                   string  `toml:"-"`
+	LogOutput              string  `toml:",omitempty"`
+	ClientTimeout          int     // seconds
+	ClientMaxRetries       int     // negative value disables retries
+	ClientRetryDelay       int     // base delay between retries (milliseconds)
+	ClientRateLimit        float64 // max requests per second (0 means no limit)
 	RalphAPIURL            string
 	RalphAPIKey            string
 	ManagementUserName     string
 	ManagementUserPassword string
+	CredentialsInEnv       *bool        `toml:",omitempty"`            // pass management credentials to scripts via environment too (see credentialsInEnv)
+	DefaultProfile         string       `toml:",omitempty"`            // profile used when none is given
+	Profile                string       `toml:"-"`                     // name of the profile in use
+	Credentials            []Credential `toml:"credentials,omitempty"` // per-host management credentials
 }
 
 // DefaultCfg provides defaults for Config. Fields with zero-values for their
 // respective fields are omitted.
 var DefaultCfg = Config{
-	ClientTimeout:          10, // seconds
+	ClientTimeout:          10,  // seconds
+	ClientMaxRetries:       3,   // see Client.do
+	ClientRetryDelay:       500, // milliseconds
 	RalphAPIURL:            "change_me",
 	RalphAPIKey:            "change_me",
 	ManagementUserName:     "change_me",
 	ManagementUserPassword: "change_me",
 }
 
+// ConfigEnvVars maps Config fields to environment variables, which can be used
+// to override them (see GetConfig).
+var ConfigEnvVars = []struct {
+	Field  string
+	EnvVar string
+}{
+	{"LogOutput", "RALPH_CLI_LOG_OUTPUT"},
+	{"ClientTimeout", "RALPH_CLI_CLIENT_TIMEOUT"},
+	{"ClientMaxRetries", "RALPH_CLI_CLIENT_MAX_RETRIES"},
+	{"ClientRetryDelay", "RALPH_CLI_CLIENT_RETRY_DELAY"},
+	{"ClientRateLimit", "RALPH_CLI_CLIENT_RATE_LIMIT"},
+	{"RalphAPIURL", "RALPH_CLI_RALPH_API_URL"},
+	{"RalphAPIKey", "RALPH_CLI_RALPH_API_KEY"},
+	{"ManagementUserName", "RALPH_CLI_MANAGEMENT_USER_NAME"},
+	{"ManagementUserPassword", "RALPH_CLI_MANAGEMENT_USER_PASSWORD"},
+	{"CredentialsInEnv", "RALPH_CLI_CREDENTIALS_IN_ENV"},
+}
+
+// ProfileEnvVar is the environment variable which can be used for selecting
+// a profile from the config file (see GetConfig).
+const ProfileEnvVar = "RALPH_CLI_PROFILE"
+
+// ConfigPathEnvVar is the environment variable which can be used for pointing
+// to a config file other than the default one (see GetConfigPath).
+const ConfigPathEnvVar = "RALPH_CLI_CONFIG"
+
 // List of files (scripts, manifests) that are bundled with ralph-cli.
 var bundledFiles = []string{
 	"idrac.py",
@@ -64,7 +106,7 @@ func GetCfgDirLocation(baseDir string) (string, error) {
 
 // PrepareCfgDir creates config dir given as cfgDir (for most cases it will ber
 // ~/.ralph-cli). It also creates default config file, and copies bundled
-// scripts to the scripts subdir.
+// scripts to the scripts subdir (unless they were removed with RemoveScript).
 func PrepareCfgDir(cfgDir, cfgFileName string) error {
 	var err error
 	if err = createCfgDir(cfgDir); err != nil {
@@ -83,9 +125,17 @@ func PrepareCfgDir(cfgDir, cfgFileName string) error {
 		}
 	}
 
-	// Copy bundled files (scripts and manifests).
+	// Copy bundled files (scripts and manifests), except the ones removed by
+	// the user (see RemoveScript).
 	var scriptsDir = filepath.Join(cfgDir, "scripts")
+	removed, err := readRemovedBundledFiles(scriptsDir)
+	if err != nil {
+		return err
+	}
 	for _, file := range bundledFiles {
+		if removed[file] {
+			continue
+		}
 		if _, err := os.Stat(filepath.Join(scriptsDir, file)); os.IsNotExist(err) {
 			if err = RestoreAsset(scriptsDir, file); err != nil {
 				return err
@@ -106,14 +156,60 @@ func createCfgDir(loc string) error {
 	return err
 }
 
-// GetConfig loads ralph-cli configuration from cfgDir (in most cases, it will be
-// ~/.ralph-cli), performs basic validation on it and supplements some of the missing
-// values with their defaults.
-func GetConfig(cfgFile string) (*Config, error) {
-	cfg, err := readConfig(cfgFile)
+// GetConfigPath returns the path to the config file, which is (in the order
+// of precedence): path given as flag (i.e. to --config switch), path given
+// via ConfigPathEnvVar, or cfgFileName in cfgDir.
+func GetConfigPath(flag, cfgDir, cfgFileName string) string {
+	switch {
+	case flag != "":
+		return flag
+	case os.Getenv(ConfigPathEnvVar) != "":
+		return os.Getenv(ConfigPathEnvVar)
+	default:
+		return filepath.Join(cfgDir, cfgFileName)
+	}
+}
+
+// GetConfig loads ralph-cli configuration from cfgFile (in most cases, it will be
+// ~/.ralph-cli/config.toml), performs basic validation on it and supplements some of
+// the missing values with their defaults.
+//
+// Config file may contain named profiles (as [profile.<name>] sections), each of them
+// overriding any of the settings given at the top level of this file (e.g. for
+// working with many Ralph instances). The profile in use is the one given as profile
+// arg (i.e. to --profile switch), or via ProfileEnvVar, or as DefaultProfile in
+// cfgFile - in that order.
+//
+// Settings from cfgFile can be overridden by environment variables (see
+// ConfigEnvVars), which in turn can be overridden by flags, given as a map from
+// Config field names to their values (e.g. "RalphAPIURL" ->
+// "http://localhost:8080/api"), so the precedence is as follows (from the lowest to
+// the highest): defaults, config file, profile, environment variables, flags.
+//
+// Values of RalphAPIKey, ManagementUserName and ManagementUserPassword may be
+// references to secrets stored elsewhere (e.g. "env:VAR" or "cmd:pass show
+// ralph/api-key" - see ResolveSecret). They are not resolved here, but only
+// when needed (see NewClient and Config.ForHost).
+func GetConfig(cfgFile, profile string, flags map[string]string) (*Config, error) {
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+	cfg, err := readConfig(cfgFile, profile)
 	if err != nil {
 		return nil, err
 	}
+	var overrides = make(map[string]string)
+	for _, v := range ConfigEnvVars {
+		if val := os.Getenv(v.EnvVar); val != "" {
+			overrides[v.Field] = val
+		}
+	}
+	for field, val := range flags {
+		overrides[field] = val
+	}
+	if err = cfg.override(overrides); err != nil {
+		return nil, err
+	}
 	err = cfg.validate()
 	if err != nil {
 		return nil, err
@@ -122,13 +218,97 @@ func GetConfig(cfgFile string) (*Config, error) {
 	return cfg, nil
 }
 
-// readConfig reads contents of cfgFile and returns it as *Config. If cfgFile points
-// to a non-existing location, then returned config will be populated with settings
-// copied from DefaultCfg.
-func readConfig(cfgFile string) (*Config, error) {
+// override sets Config fields given as keys in overrides to the values parsed
+// from strings given as values in overrides. All the errors are returned at
+// once, via ValidationError.
+func (c *Config) override(overrides map[string]string) error {
+	var errMsgs []*string
+	v := reflect.ValueOf(c).Elem()
+	// Iterating over ConfigEnvVars instead of overrides gives us a stable
+	// order of error messages.
+	for _, ev := range ConfigEnvVars {
+		val, ok := overrides[ev.Field]
+		if !ok {
+			continue
+		}
+		f := v.FieldByName(ev.Field)
+		var err error
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(val)
+		case reflect.Int:
+			var i int64
+			if i, err = strconv.ParseInt(val, 10, 0); err == nil {
+				f.SetInt(i)
+			}
+		case reflect.Float64:
+			var fl float64
+			if fl, err = strconv.ParseFloat(val, 64); err == nil {
+				f.SetFloat(fl)
+			}
+		case reflect.Bool:
+			var b bool
+			if b, err = strconv.ParseBool(val); err == nil {
+				f.SetBool(b)
+			}
+		case reflect.Ptr: // *bool
+			var b bool
+			if b, err = strconv.ParseBool(val); err == nil {
+				f.Set(reflect.ValueOf(&b))
+			}
+		}
+		if err != nil {
+			msg := fmt.Sprintf("invalid value for %s: %q", ev.Field, val)
+			errMsgs = append(errMsgs, &msg)
+		}
+	}
+	if len(errMsgs) > 0 {
+		return NewValidationError(c.location(), errMsgs)
+	}
+	return nil
+}
+
+// credentialsInEnv returns true if management credentials should be passed to
+// scan scripts via environment, and not only in the context file (see
+// prepareEnv). Until CredentialsInEnv is set explicitly, they are passed this
+// way (for compatibility with scripts written for older versions of
+// ralph-cli), but it's deprecated, so a warning is logged (once).
+func (c *Config) credentialsInEnv() bool {
+	if c.CredentialsInEnv != nil {
+		return *c.CredentialsInEnv
+	}
+	credentialsInEnvWarning.Do(func() {
+		log.Println("WARNING: Management credentials are passed to scan scripts as MANAGEMENT_USER_NAME " +
+			"and MANAGEMENT_USER_PASSWORD environment variables, which is deprecated and will be disabled " +
+			"by default in the next release. Scripts should read them from the context file instead " +
+			"(see RALPH_CLI_CONTEXT). To keep passing them via environment, set CredentialsInEnv = true " +
+			"in the config file (or false, to stop doing it now).")
+	})
+	return true
+}
+
+var credentialsInEnvWarning sync.Once
+
+// location returns the path to the file from which Config was read, for use
+// in error messages.
+func (c *Config) location() string {
+	if c.Path == "" {
+		return "config (no config file, defaults used)"
+	}
+	return c.Path
+}
+
+// readConfig reads contents of cfgFile and returns it as *Config, with settings
+// from a given profile applied (if profile is an empty string, DefaultProfile from
+// cfgFile is used, if any). If cfgFile points to a non-existing location, then
+// returned config will be populated with settings copied from DefaultCfg.
+func readConfig(cfgFile, profile string) (*Config, error) {
 	var cfg Config
 	switch {
 	case !fileExists(cfgFile):
+		if profile != "" {
+			return nil, fmt.Errorf("unknown profile %q (config file %s does not exist)", profile, cfgFile)
+		}
 		cfg = DefaultCfg
 	default:
 		cfg.Path = cfgFile
@@ -138,10 +318,45 @@ func readConfig(cfgFile string) (*Config, error) {
 		if _, err := toml.DecodeFile(cfgFile, &cfg); err != nil {
 			return nil, err
 		}
+		if profile == "" {
+			profile = cfg.DefaultProfile
+		}
+		if profile != "" {
+			if err := cfg.applyProfile(profile); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return &cfg, nil
 }
 
+// applyProfile is a helper method for readConfig, which overrides Config
+// fields with the ones given in [profile.<name>] section of the config file.
+func (c *Config) applyProfile(name string) error {
+	var raw struct {
+		Profile map[string]toml.Primitive
+	}
+	md, err := toml.DecodeFile(c.Path, &raw)
+	if err != nil {
+		return err
+	}
+	p, ok := raw.Profile[name]
+	if !ok {
+		var names []string
+		for n := range raw.Profile {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown profile %q in config file %s (available profiles: %s)",
+			name, c.Path, strings.Join(names, ", "))
+	}
+	if err := md.PrimitiveDecode(p, c); err != nil {
+		return fmt.Errorf("error reading profile %q from config file %s: %v", name, c.Path, err)
+	}
+	c.Profile = name
+	return nil
+}
+
 func fileExists(file string) bool {
 	_, err := os.Stat(file)
 	if err != nil {
@@ -179,14 +394,29 @@ func (c *Config) validate() error {
 	// "httplocalhost" or "http/localhost/api", and add some additional checks
 	// here for such cases.
 	// TODO(xor-xor): Get rid of Query/Fragment if present in URL.
+	if c.ClientRetryDelay < 0 {
+		msg := fmt.Sprint("ClientRetryDelay should not be negative")
+		errMsgs = append(errMsgs, &msg)
+	}
+	if c.ClientRateLimit < 0 {
+		msg := fmt.Sprint("ClientRateLimit should not be negative")
+		errMsgs = append(errMsgs, &msg)
+	}
+	for i, cred := range c.Credentials {
+		for _, m := range cred.validate() {
+			msg := fmt.Sprintf("credentials #%d: %s", i, m)
+			errMsgs = append(errMsgs, &msg)
+		}
+	}
 	u, err := url.Parse(c.RalphAPIURL)
 	if err != nil {
 		msg := fmt.Sprintf("error while parsing Ralph API URL: %v", err)
 		errMsgs = append(errMsgs, &msg)
+	} else {
+		c.RalphAPIURL = u.String()
 	}
-	c.RalphAPIURL = u.String()
 	if len(errMsgs) > 0 {
-		return NewValidationError(c.Path, errMsgs)
+		return NewValidationError(c.location(), errMsgs)
 	}
 	return nil
 }
@@ -195,10 +425,15 @@ func (c *Config) validate() error {
 func (c *Config) getDefaults() {
 	// Unfortunately, there's no easy way to iterate over struct fields, hence
 	// we need to enumerate default settings manually here.
-	switch {
-	case c.ClientTimeout == 0:
+	if c.ClientTimeout == 0 {
 		c.ClientTimeout = DefaultCfg.ClientTimeout
 	}
+	if c.ClientMaxRetries == 0 {
+		c.ClientMaxRetries = DefaultCfg.ClientMaxRetries
+	}
+	if c.ClientRetryDelay == 0 {
+		c.ClientRetryDelay = DefaultCfg.ClientRetryDelay
+	}
 }
 
 // Manifest represents the contents of a .toml file holding additional
@@ -208,7 +443,13 @@ type Manifest struct {
 	Path            string `toml:"-"`
 	Language        string
 	LanguageVersion int
-	Requirements    []requirement `toml:"requirement"`
+	Requirements    []requirement     `toml:"requirement"`
+	Interpreter     string            // program launching the script (e.g. /bin/bash)
+	Command         string            // program launching the script, overriding the default one (template)
+	Args            []string          // extra args for the script, or for Command when given (templates)
+	Env             map[string]string // extra environment variables (values are templates)
+	WorkDir         string            // working dir for the script (relative to the scripts dir)
+	Timeout         int               // in seconds (zero means no timeout)
 }
 
 // requirement is a helper type for Manifest. It shouldn't be used
@@ -251,12 +492,24 @@ func (m *Manifest) validate() error {
 		msg := fmt.Sprint("LanguageVersion field for Python should be either 2 or 3")
 		errMsgs = append(errMsgs, &msg)
 	}
+	if m.Timeout < 0 {
+		msg := fmt.Sprint("Timeout should not be negative")
+		errMsgs = append(errMsgs, &msg)
+	}
+	if m.Language != "" && m.Language != "python" && len(m.Requirements) > 0 {
+		msg := fmt.Sprint("requirements are supported only for Python scripts")
+		errMsgs = append(errMsgs, &msg)
+	}
 	for _, r := range m.Requirements {
 		if r.Name == "" {
 			msg := fmt.Sprint("unknown requirement (empty name field)")
 			errMsgs = append(errMsgs, &msg)
 		}
 	}
+	for _, rtMsg := range m.validateRuntime() {
+		msg := rtMsg
+		errMsgs = append(errMsgs, &msg)
+	}
 	if len(errMsgs) > 0 {
 		return NewValidationError(m.Path, errMsgs)
 	}
@@ -307,20 +560,9 @@ func CreatePythonVenv(s Script) (venvPath string, err error) {
 // a given Script, and installs them with "pip install" into a virtualenv
 // pointed by venvPath.
 func InstallPythonReqs(venvPath string, s Script) error {
-	numReqs := len(s.Manifest.Requirements)
-	if numReqs > 0 {
-		var req string
-		var args = make([]string, numReqs+1)
-		args[0] = "install"
-		for i, r := range s.Manifest.Requirements {
-			switch {
-			case r.Version == "":
-				req = r.Name
-			default:
-				req = strings.Join([]string{r.Name, r.Version}, "==")
-			}
-			args[i+1] = req
-		}
+	reqs := s.Manifest.pipRequirements()
+	if len(reqs) > 0 {
+		args := append([]string{"install"}, reqs...)
 		pip := filepath.Join(venvPath, "bin", "pip")
 		cmd := execCommand(pip, args...)
 		if output, err := cmd.CombinedOutput(); err != nil {
@@ -330,3 +572,157 @@ func InstallPythonReqs(venvPath string, s Script) error {
 	}
 	return nil
 }
+
+// pipRequirements returns Requirements from Manifest in the form accepted by
+// "pip install" (e.g. "requests==2.10.0").
+func (m *Manifest) pipRequirements() []string {
+	var reqs []string
+	for _, r := range m.Requirements {
+		switch {
+		case r.Version == "":
+			reqs = append(reqs, r.Name)
+		default:
+			reqs = append(reqs, strings.Join([]string{r.Name, r.Version}, "=="))
+		}
+	}
+	return reqs
+}
+
+// venvStateFile is the name of a file (stored inside virtualenv) holding
+// venvState.
+const venvStateFile = "ralph-cli-manifest.toml"
+
+// venvState describes the Manifest which given virtualenv has been prepared
+// for, so it can be detected when this Manifest changes (see PrepareVenv).
+type venvState struct {
+	LanguageVersion  int
+	RequirementsHash string
+}
+
+// newVenvState creates venvState for Manifest m. Requirements are hashed in
+// the form in which they are passed to pip (see pipRequirements).
+func newVenvState(m *Manifest) venvState {
+	h := sha256.New()
+	for _, r := range m.pipRequirements() {
+		fmt.Fprintln(h, r)
+	}
+	return venvState{
+		LanguageVersion:  m.LanguageVersion,
+		RequirementsHash: hex.EncodeToString(h.Sum(nil)),
+	}
+}
+
+// readVenvState reads venvState stored in virtualenv given as venvPath. It
+// returns nil (and no error) when there's no such state (e.g. for virtualenvs
+// created by older versions of ralph-cli).
+func readVenvState(venvPath string) (*venvState, error) {
+	var vs venvState
+	path := filepath.Join(venvPath, venvStateFile)
+	if !fileExists(path) {
+		return nil, nil
+	}
+	if _, err := toml.DecodeFile(path, &vs); err != nil {
+		return nil, fmt.Errorf("error reading virtualenv state from %s: %v", path, err)
+	}
+	return &vs, nil
+}
+
+// writeVenvState stores venvState for Manifest m in virtualenv given as
+// venvPath.
+func writeVenvState(venvPath string, m *Manifest) error {
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(newVenvState(m)); err != nil {
+		return err
+	}
+	path := filepath.Join(venvPath, venvStateFile)
+	if err := ioutil.WriteFile(path, buf.Bytes(), os.FileMode(0644)); err != nil {
+		return fmt.Errorf("error writing virtualenv state to %s: %v", path, err)
+	}
+	return nil
+}
+
+// VenvOutdated returns true if there's a virtualenv for a given Python script,
+// but it doesn't reflect its current Manifest (i.e. PrepareVenv would update or
+// rebuild it).
+func VenvOutdated(s Script) bool {
+	if s.Manifest == nil || !VenvExists(s) {
+		return false
+	}
+	vs, err := readVenvState(MakeVenvPath(s))
+	if err != nil || vs == nil {
+		return true
+	}
+	return *vs != newVenvState(s.Manifest)
+}
+
+// VenvStatus tells what has been done by PrepareVenv.
+type VenvStatus int
+
+// Possible values of VenvStatus.
+const (
+	VenvUpToDate VenvStatus = iota // nothing needed to be done
+	VenvCreated                    // virtualenv didn't exist
+	VenvUpdated                    // requirements have been (re)installed
+	VenvRebuilt                    // virtualenv has been recreated from scratch
+)
+
+func (vs VenvStatus) String() string {
+	switch vs {
+	case VenvCreated:
+		return "created"
+	case VenvUpdated:
+		return "updated"
+	case VenvRebuilt:
+		return "rebuilt"
+	default:
+		return "up to date"
+	}
+}
+
+// PrepareVenv makes sure that the virtualenv for a given Python script
+// reflects its Manifest. The virtualenv is created when it doesn't exist, and
+// recreated from scratch when rebuild is true or LanguageVersion in Manifest
+// has changed. When only the requirements have changed, they are installed
+// again into the existing virtualenv (please note that requirements removed
+// from Manifest are not uninstalled this way - use rebuild for that).
+func PrepareVenv(s Script, rebuild bool) (VenvStatus, error) {
+	venvPath := MakeVenvPath(s)
+	status := VenvUpToDate
+	switch {
+	case !VenvExists(s):
+		status = VenvCreated
+	case rebuild:
+		status = VenvRebuilt
+	default:
+		vs, err := readVenvState(venvPath)
+		if err != nil {
+			return status, err
+		}
+		want := newVenvState(s.Manifest)
+		switch {
+		case vs == nil:
+			// Version of Python is unknown, but it's better to assume that it
+			// didn't change than to rebuild all the existing virtualenvs.
+			status = VenvUpdated
+		case vs.LanguageVersion != want.LanguageVersion:
+			status = VenvRebuilt
+		case vs.RequirementsHash != want.RequirementsHash:
+			status = VenvUpdated
+		}
+	}
+	switch status {
+	case VenvUpToDate:
+		return status, nil
+	case VenvCreated, VenvRebuilt:
+		if err := os.RemoveAll(venvPath); err != nil {
+			return status, fmt.Errorf("error removing python virtualenv: %v", err)
+		}
+		if _, err := CreatePythonVenv(s); err != nil {
+			return status, err
+		}
+	}
+	if err := InstallPythonReqs(venvPath, s); err != nil {
+		return status, err
+	}
+	return status, writeVenvState(venvPath, s.Manifest)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"os/user"
@@ -18,10 +19,14 @@ var configTestFixturesDir = "./config_test_fixtures"
 // files with fixtures before running tests from this file.
 func init() {
 	var perms = map[string]os.FileMode{
-		"config.toml":                   0600,
-		"config_api_key_missing.toml":   0600,
-		"config_api_url_missing.toml":   0600,
-		"config_wrong_permissions.toml": 0666,
+		"config.toml":                     0600,
+		"config_api_key_missing.toml":     0600,
+		"config_api_url_missing.toml":     0600,
+		"config_client_settings.toml":     0600,
+		"config_credentials.toml":         0600,
+		"config_credentials_invalid.toml": 0600,
+		"config_profiles.toml":            0600,
+		"config_wrong_permissions.toml":   0666,
 	}
 	for fileName, mode := range perms {
 		err := os.Chmod(filepath.Join(configTestFixturesDir, fileName), mode)
@@ -53,6 +58,8 @@ func TestGetConfig(t *testing.T) {
 				Path:                   filepath.Join(configTestFixturesDir, "config.toml"),
 				LogOutput:              "",
 				ClientTimeout:          10,
+				ClientMaxRetries:       3,
+				ClientRetryDelay:       500,
 				RalphAPIURL:            "http://localhost:8080/api",
 				RalphAPIKey:            "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
 				ManagementUserName:     "some_user",
@@ -80,6 +87,8 @@ func TestGetConfig(t *testing.T) {
 			want: &Config{
 				LogOutput:              "",
 				ClientTimeout:          10,
+				ClientMaxRetries:       3,
+				ClientRetryDelay:       500,
 				RalphAPIURL:            "change_me",
 				RalphAPIKey:            "change_me",
 				ManagementUserName:     "change_me",
@@ -87,10 +96,48 @@ func TestGetConfig(t *testing.T) {
 			},
 			errMsg: "",
 		},
+		"#5 Client settings": {
+			fixtureFile: "config_client_settings.toml",
+			want: &Config{
+				Path:                   filepath.Join(configTestFixturesDir, "config_client_settings.toml"),
+				ClientTimeout:          10,
+				ClientMaxRetries:       -1,
+				ClientRetryDelay:       250,
+				ClientRateLimit:        2.5,
+				RalphAPIURL:            "http://localhost:8080/api",
+				RalphAPIKey:            "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+			},
+			errMsg: "",
+		},
+		"#6 Per-host credentials": {
+			fixtureFile: "config_credentials.toml",
+			want: &Config{
+				Path:                   filepath.Join(configTestFixturesDir, "config_credentials.toml"),
+				ClientTimeout:          10,
+				ClientMaxRetries:       3,
+				ClientRetryDelay:       500,
+				RalphAPIURL:            "http://localhost:8080/api",
+				RalphAPIKey:            "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+				Credentials: []Credential{
+					{Network: "10.20.0.0/16", ManagementUserName: "dc1_user", ManagementUserPassword: "dc1_password"},
+					{Host: "*.lab.local", Script: "idrac.py", ManagementUserPassword: "lab_password"},
+				},
+			},
+			errMsg: "",
+		},
+		"#7 Invalid per-host credentials": {
+			fixtureFile: "config_credentials_invalid.toml",
+			want:        nil,
+			errMsg:      "credentials #1: at least one of Network, Host or Script fields should be given",
+		},
 	}
 	for tn, tc := range cases {
 		cfgFile := filepath.Join(configTestFixturesDir, tc.fixtureFile)
-		got, err := GetConfig(cfgFile)
+		got, err := GetConfig(cfgFile, "", nil)
 		switch {
 		case tc.errMsg != "":
 			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
@@ -100,13 +147,232 @@ func TestGetConfig(t *testing.T) {
 			if err != nil {
 				t.Fatalf("err: %s", err)
 			}
-			if *got != *tc.want {
-				t.Errorf("%s\n got: %+v\nwant: %+v", tn, *got, *tc.want)
+			if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+				t.Errorf("%s\n%s", tn, err)
+			}
+		}
+	}
+}
+
+func TestGetConfigOverrides(t *testing.T) {
+	var cases = map[string]struct {
+		fixtureFile string
+		env         map[string]string
+		flags       map[string]string
+		want        *Config
+		errMsg      string
+	}{
+		"#0 Environment variables take precedence over config file": {
+			fixtureFile: "config.toml",
+			env: map[string]string{
+				"RALPH_CLI_RALPH_API_URL":     "http://ralph.local/api",
+				"RALPH_CLI_CLIENT_TIMEOUT":    "30",
+				"RALPH_CLI_CLIENT_RATE_LIMIT": "0.5",
+			},
+			want: &Config{
+				Path:                   filepath.Join(configTestFixturesDir, "config.toml"),
+				ClientTimeout:          30,
+				ClientMaxRetries:       3,
+				ClientRetryDelay:       500,
+				ClientRateLimit:        0.5,
+				RalphAPIURL:            "http://ralph.local/api",
+				RalphAPIKey:            "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+			},
+		},
+		"#1 Flags take precedence over environment variables": {
+			fixtureFile: "config.toml",
+			env: map[string]string{
+				"RALPH_CLI_RALPH_API_URL": "http://ralph.local/api",
+				"RALPH_CLI_RALPH_API_KEY": "key_from_env",
+			},
+			flags: map[string]string{
+				"RalphAPIURL":   "http://other-ralph.local/api",
+				"ClientTimeout": "5",
+			},
+			want: &Config{
+				Path:                   filepath.Join(configTestFixturesDir, "config.toml"),
+				ClientTimeout:          5,
+				ClientMaxRetries:       3,
+				ClientRetryDelay:       500,
+				RalphAPIURL:            "http://other-ralph.local/api",
+				RalphAPIKey:            "key_from_env",
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+			},
+		},
+		"#2 No config file at all": {
+			fixtureFile: "does_not_exist.toml",
+			env: map[string]string{
+				"RALPH_CLI_RALPH_API_URL":            "http://ralph.local/api",
+				"RALPH_CLI_RALPH_API_KEY":            "key_from_env",
+				"RALPH_CLI_MANAGEMENT_USER_NAME":     "user_from_env",
+				"RALPH_CLI_MANAGEMENT_USER_PASSWORD": "password_from_env",
+				"RALPH_CLI_LOG_OUTPUT":               "stderr",
+				"RALPH_CLI_CREDENTIALS_IN_ENV":       "true",
+			},
+			want: &Config{
+				LogOutput:              "stderr",
+				ClientTimeout:          10,
+				ClientMaxRetries:       3,
+				ClientRetryDelay:       500,
+				RalphAPIURL:            "http://ralph.local/api",
+				RalphAPIKey:            "key_from_env",
+				ManagementUserName:     "user_from_env",
+				ManagementUserPassword: "password_from_env",
+				CredentialsInEnv:       PtrToBool(true),
+			},
+		},
+		"#3 Invalid values": {
+			fixtureFile: "config.toml",
+			env: map[string]string{
+				"RALPH_CLI_CLIENT_MAX_RETRIES": "many",
+			},
+			flags: map[string]string{
+				"ClientTimeout": "10s",
+			},
+			errMsg: `invalid value for ClientTimeout: "10s"`,
+		},
+		"#4 Invalid Ralph API URL": {
+			fixtureFile: "config.toml",
+			flags: map[string]string{
+				"RalphAPIURL": ":bad",
+			},
+			errMsg: "error while parsing Ralph API URL",
+		},
+	}
+
+	for tn, tc := range cases {
+		for k, v := range tc.env {
+			os.Setenv(k, v)
+		}
+		cfgFile := filepath.Join(configTestFixturesDir, tc.fixtureFile)
+		got, err := GetConfig(cfgFile, "", tc.flags)
+		for k := range tc.env {
+			os.Unsetenv(k)
+		}
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+				t.Errorf("%s\n%s", tn, err)
+			}
+		}
+	}
+}
+
+func TestGetConfigProfiles(t *testing.T) {
+	var fixturePath = filepath.Join(configTestFixturesDir, "config_profiles.toml")
+	var base = Config{
+		Path:                   fixturePath,
+		ClientTimeout:          10,
+		ClientMaxRetries:       3,
+		ClientRetryDelay:       500,
+		RalphAPIURL:            "https://ralph.local/api",
+		RalphAPIKey:            "production_key",
+		ManagementUserName:     "some_user",
+		ManagementUserPassword: "some_password",
+		DefaultProfile:         "staging",
+	}
+	var staging = base
+	staging.Profile = "staging"
+	staging.RalphAPIURL = "https://ralph-staging.local/api"
+	staging.RalphAPIKey = "staging_key"
+	var lab = base
+	lab.Profile = "lab"
+	lab.RalphAPIURL = "http://ralph-lab.local/api"
+	lab.RalphAPIKey = "lab_key"
+	lab.ManagementUserName = "lab_user"
+	lab.ManagementUserPassword = "lab_password"
+	lab.ClientTimeout = 60
+	var labFromEnv = lab
+	labFromEnv.RalphAPIKey = "key_from_env"
+
+	var cases = map[string]struct {
+		profile string
+		env     map[string]string
+		want    *Config
+		errMsg  string
+	}{
+		"#0 Default profile": {
+			want: &staging,
+		},
+		"#1 Profile given explicitly": {
+			profile: "lab",
+			want:    &lab,
+		},
+		"#2 Profile given via env var": {
+			env:  map[string]string{"RALPH_CLI_PROFILE": "lab"},
+			want: &lab,
+		},
+		"#3 Profile given explicitly takes precedence over env var": {
+			profile: "lab",
+			env:     map[string]string{"RALPH_CLI_PROFILE": "staging"},
+			want:    &lab,
+		},
+		"#4 Env vars take precedence over profile": {
+			profile: "lab",
+			env:     map[string]string{"RALPH_CLI_RALPH_API_KEY": "key_from_env"},
+			want:    &labFromEnv,
+		},
+		"#5 Unknown profile": {
+			profile: "prod",
+			errMsg:  `unknown profile "prod" in config file config_test_fixtures/config_profiles.toml (available profiles: lab, staging)`,
+		},
+	}
+
+	for tn, tc := range cases {
+		for k, v := range tc.env {
+			os.Setenv(k, v)
+		}
+		got, err := GetConfig(fixturePath, tc.profile, nil)
+		for k := range tc.env {
+			os.Unsetenv(k)
+		}
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+				t.Errorf("%s\n%s", tn, err)
 			}
 		}
 	}
 }
 
+func TestGetConfigPath(t *testing.T) {
+	var cases = map[string]struct {
+		flag string
+		env  string
+		want string
+	}{
+		"#0 Default path":          {"", "", "/home/user/.ralph-cli/config.toml"},
+		"#1 Path from env var":     {"", "/etc/ralph-cli.toml", "/etc/ralph-cli.toml"},
+		"#2 Flag takes precedence": {"/tmp/ralph-cli.toml", "/etc/ralph-cli.toml", "/tmp/ralph-cli.toml"},
+	}
+
+	for tn, tc := range cases {
+		os.Setenv(ConfigPathEnvVar, tc.env)
+		got := GetConfigPath(tc.flag, "/home/user/.ralph-cli", "config.toml")
+		os.Unsetenv(ConfigPathEnvVar)
+		if got != tc.want {
+			t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
+		}
+	}
+}
+
 func TestGetCfgDirLocation(t *testing.T) {
 	user, err := user.Current()
 	if err != nil {
@@ -229,6 +495,31 @@ func TestValidateManifest(t *testing.T) {
 			},
 			want: fmt.Errorf("validation error in %s: unknown requirement (empty name field)", manifestPath),
 		},
+		"#4 Valid manifest for Go script": {
+			manifest: &Manifest{
+				Path:     manifestPath,
+				Language: "go",
+				Args:     []string{"--host", "{{.Host}}"},
+				Env:      map[string]string{"COLLECTOR_CACHE": "{{.WorkDir}}/cache"},
+				WorkDir:  "collector",
+			},
+			want: nil,
+		},
+		"#5 Invalid runtime settings": {
+			manifest: &Manifest{
+				Path:         manifestPath,
+				Language:     "shell",
+				Requirements: []requirement{{Name: "requests"}},
+				Command:      "{{.Interpreter",
+				Args:         []string{"{{.Unknown}}"},
+				Env:          map[string]string{"IP_TO_SCAN": "127.0.0.1"},
+			},
+			want: fmt.Errorf("validation errors in %s (4 in total): "+
+				"(1) requirements are supported only for Python scripts; "+
+				"(2) environment variable IP_TO_SCAN can't be overridden in Env; "+
+				"(3) invalid template in Args[0]: template: :1:2: executing \"\" at <.Unknown>: can't evaluate field Unknown in type main.CommandVars; "+
+				"(4) invalid template in Command: template: :1: unclosed action", manifestPath),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -318,3 +609,123 @@ func TestCreatePythonVenv(t *testing.T) {
 		t.Errorf("\n got: %v\nwant: %v", got, want)
 	}
 }
+
+// Helper process for TestPrepareVenv - it creates a fake virtualenv (when
+// called as virtualenv), and records requirements installed into it (when
+// called as pip).
+func TestVenvHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	args = args[1:]
+	switch {
+	case args[0] == "virtualenv":
+		venvPath := args[len(args)-1]
+		if err := os.MkdirAll(filepath.Join(venvPath, "bin"), os.FileMode(0755)); err != nil {
+			os.Exit(1)
+		}
+		if err := ioutil.WriteFile(filepath.Join(venvPath, "bin", "activate"), nil, os.FileMode(0644)); err != nil {
+			os.Exit(1)
+		}
+	case filepath.Base(args[0]) == "pip":
+		log := filepath.Join(filepath.Dir(args[0]), "pip.log")
+		f, err := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+		if err != nil {
+			os.Exit(1)
+		}
+		fmt.Fprintln(f, strings.Join(args[1:], " "))
+		f.Close()
+	}
+	os.Exit(0)
+}
+
+func TestPrepareVenv(t *testing.T) {
+	execCommand = GetHelperCommand("TestVenvHelperProcess")
+	defer func() { execCommand = exec.Command }()
+
+	cfgDir, baseDir, err := GetTempCfgDir()
+	defer os.RemoveAll(baseDir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	script := Script{
+		Path: filepath.Join(cfgDir, "scripts", "idrac.py"),
+		Manifest: &Manifest{
+			Language:        "python",
+			LanguageVersion: 3,
+			Requirements:    []requirement{{"requests", "2.10.0"}},
+		},
+	}
+	pipLog := filepath.Join(MakeVenvPath(script), "bin", "pip.log")
+
+	// Steps are executed in order, each of them on the virtualenv left by the
+	// previous one.
+	var steps = []struct {
+		desc    string
+		modify  func(m *Manifest)
+		rebuild bool
+		want    VenvStatus
+		wantLog string
+	}{
+		{
+			desc:    "#0 Virtualenv doesn't exist",
+			want:    VenvCreated,
+			wantLog: "install requests==2.10.0\n",
+		},
+		{
+			desc:    "#1 Nothing changed",
+			want:    VenvUpToDate,
+			wantLog: "install requests==2.10.0\n",
+		},
+		{
+			desc: "#2 Requirement added",
+			modify: func(m *Manifest) {
+				m.Requirements = append(m.Requirements, requirement{"pyaml", ""})
+			},
+			want:    VenvUpdated,
+			wantLog: "install requests==2.10.0\ninstall requests==2.10.0 pyaml\n",
+		},
+		{
+			desc:    "#3 Language version changed",
+			modify:  func(m *Manifest) { m.LanguageVersion = 2 },
+			want:    VenvRebuilt,
+			wantLog: "install requests==2.10.0 pyaml\n",
+		},
+		{
+			desc:    "#4 Rebuild requested",
+			rebuild: true,
+			want:    VenvRebuilt,
+			wantLog: "install requests==2.10.0 pyaml\n",
+		},
+	}
+
+	for _, step := range steps {
+		if step.modify != nil {
+			step.modify(script.Manifest)
+			if !VenvOutdated(script) {
+				t.Errorf("%s\nvirtualenv should be reported as outdated", step.desc)
+			}
+		}
+		got, err := PrepareVenv(script, step.rebuild)
+		if err != nil {
+			t.Fatalf("%s\nerr: %s", step.desc, err)
+		}
+		if got != step.want {
+			t.Errorf("%s\n got: %s\nwant: %s", step.desc, got, step.want)
+		}
+		log, err := ioutil.ReadFile(pipLog)
+		if err != nil {
+			t.Fatalf("%s\nerr: %s", step.desc, err)
+		}
+		if string(log) != step.wantLog {
+			t.Errorf("%s\n got pip calls: %q\nwant pip calls: %q", step.desc, log, step.wantLog)
+		}
+		if VenvOutdated(script) {
+			t.Errorf("%s\nvirtualenv shouldn't be reported as outdated", step.desc)
+		}
+	}
+}
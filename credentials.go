@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"strings"
+)
+
+// Credential holds management credentials (i.e. the ones exposed to scan
+// scripts as MANAGEMENT_USER_NAME and MANAGEMENT_USER_PASSWORD) meant for
+// hosts matching all of its criteria: Network (in CIDR notation, e.g.
+// "10.20.0.0/16"), Host (a glob pattern, e.g. "*.dc1.example.com") and Script
+// (name of the scan script, e.g. "ilo.py"). Criteria given as empty strings
+// are ignored, as well as empty credentials (the ones from the top level of
+// the config are used instead). Credentials are given in the config file as
+// [[credentials]] tables, and their values may be references to secrets (see
+// ResolveSecret).
+type Credential struct {
+	Network                string
+	Host                   string
+	Script                 string
+	ManagementUserName     string
+	ManagementUserPassword string
+}
+
+// lookupAddr is used by Credential.matches for finding hostnames of scanned
+// IP addresses (it's a variable to facilitate testing).
+var lookupAddr = net.LookupAddr
+
+// validate performs sanity checks on Credential, returning error messages
+// for all the problems found (or nil, if there aren't any).
+func (c Credential) validate() []string {
+	var errMsgs []string
+	if c.Network == "" && c.Host == "" && c.Script == "" {
+		errMsgs = append(errMsgs, "at least one of Network, Host or Script fields should be given")
+	}
+	if c.Network != "" {
+		if _, _, err := net.ParseCIDR(c.Network); err != nil {
+			errMsgs = append(errMsgs, fmt.Sprintf("invalid Network: %v", err))
+		}
+	}
+	if c.Host != "" {
+		if _, err := path.Match(c.Host, ""); err != nil {
+			errMsgs = append(errMsgs, fmt.Sprintf("invalid Host pattern %q: %v", c.Host, err))
+		}
+	}
+	return errMsgs
+}
+
+// matches returns true if addr scanned with a script named scriptName meets
+// all the criteria of Credential. Host pattern is matched against addr itself
+// (when it's a hostname), or against hostnames found by reverse DNS lookup
+// (when it's an IP address).
+func (c Credential) matches(addr Addr, scriptName string) bool {
+	if c.Script != "" && c.Script != scriptName {
+		return false
+	}
+	ip := net.ParseIP(string(addr))
+	if c.Network != "" {
+		_, ipNet, err := net.ParseCIDR(c.Network)
+		if err != nil || ip == nil || !ipNet.Contains(ip) {
+			return false
+		}
+	}
+	if c.Host != "" {
+		var hostnames = []string{string(addr)}
+		if ip != nil {
+			// Lookup errors simply mean that there are no hostnames to match.
+			hostnames, _ = lookupAddr(string(addr))
+		}
+		var found bool
+		for _, h := range hostnames {
+			if ok, _ := path.Match(c.Host, strings.TrimSuffix(h, ".")); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ForHost returns a copy of Config with management credentials replaced with
+// the ones from the first Credential matching addr scanned with a script
+// named scriptName (if there's no such Credential, the ones from Config are
+// used). References to secrets in these credentials are resolved here, i.e.
+// only when needed, and only once for all the hosts (see resolveSecretOnce).
+func (c *Config) ForHost(addr Addr, scriptName string) (*Config, error) {
+	hostCfg := *c
+	var fields = []struct {
+		name   string
+		dst    *string
+		source string // for error messages
+	}{
+		{"ManagementUserName", &hostCfg.ManagementUserName, ""},
+		{"ManagementUserPassword", &hostCfg.ManagementUserPassword, ""},
+	}
+	for i, cred := range c.Credentials {
+		if !cred.matches(addr, scriptName) {
+			continue
+		}
+		for j, value := range []string{cred.ManagementUserName, cred.ManagementUserPassword} {
+			if value == "" {
+				continue
+			}
+			*fields[j].dst = value
+			fields[j].source = fmt.Sprintf(" from credentials #%d", i)
+		}
+		break
+	}
+	for _, f := range fields {
+		secret, err := resolveSecretOnce(*f.dst)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving %s%s: %v", f.name, f.source, err)
+		}
+		*f.dst = secret
+	}
+	return &hostCfg, nil
+}
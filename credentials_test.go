@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCredentialMatches(t *testing.T) {
+	lookupAddr = func(addr string) ([]string, error) {
+		switch addr {
+		case "10.20.1.5":
+			return []string{"srv1.dc1.example.com."}, nil
+		default:
+			return nil, fmt.Errorf("lookup %s: no such host", addr)
+		}
+	}
+	defer func() { lookupAddr = net.LookupAddr }()
+
+	var cases = map[string]struct {
+		cred   Credential
+		addr   Addr
+		script string
+		want   bool
+	}{
+		"#0 IP within network": {
+			cred: Credential{Network: "10.20.0.0/16"},
+			addr: "10.20.1.5",
+			want: true,
+		},
+		"#1 IP outside network": {
+			cred: Credential{Network: "10.20.0.0/16"},
+			addr: "10.30.1.5",
+			want: false,
+		},
+		"#2 Hostname never matches network": {
+			cred: Credential{Network: "10.20.0.0/16"},
+			addr: "srv1.dc1.example.com",
+			want: false,
+		},
+		"#3 Hostname matching pattern": {
+			cred: Credential{Host: "*.dc1.example.com"},
+			addr: "srv1.dc1.example.com",
+			want: true,
+		},
+		"#4 IP with reverse DNS matching pattern": {
+			cred: Credential{Host: "*.dc1.example.com"},
+			addr: "10.20.1.5",
+			want: true,
+		},
+		"#5 IP without reverse DNS": {
+			cred: Credential{Host: "*.dc1.example.com"},
+			addr: "10.30.1.5",
+			want: false,
+		},
+		"#6 Script name": {
+			cred:   Credential{Script: "idrac.py"},
+			addr:   "10.20.1.5",
+			script: "idrac.py",
+			want:   true,
+		},
+		"#7 All criteria have to be met": {
+			cred:   Credential{Network: "10.20.0.0/16", Script: "idrac.py"},
+			addr:   "10.20.1.5",
+			script: "ilo.py",
+			want:   false,
+		},
+	}
+
+	for tn, tc := range cases {
+		got := tc.cred.matches(tc.addr, tc.script)
+		if got != tc.want {
+			t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
+		}
+	}
+}
+
+func TestCredentialValidate(t *testing.T) {
+	var cases = map[string]struct {
+		cred Credential
+		want []string
+	}{
+		"#0 Valid": {
+			cred: Credential{Network: "10.20.0.0/16", Host: "*.example.com"},
+			want: nil,
+		},
+		"#1 No criteria": {
+			cred: Credential{ManagementUserName: "some_user"},
+			want: []string{"at least one of Network, Host or Script fields should be given"},
+		},
+		"#2 Invalid network and pattern": {
+			cred: Credential{Network: "10.20.0.0", Host: "[a-"},
+			want: []string{
+				"invalid Network: invalid CIDR address: 10.20.0.0",
+				`invalid Host pattern "[a-": syntax error in pattern`,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		got := tc.cred.validate()
+		if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+			t.Errorf("%s\n got: %q\nwant: %q", tn, got, tc.want)
+		}
+	}
+}
+
+func TestConfigForHost(t *testing.T) {
+	os.Setenv("RALPH_CLI_TEST_SECRET", "secret_from_env")
+	defer os.Unsetenv("RALPH_CLI_TEST_SECRET")
+	os.Setenv("RALPH_CLI_TEST_USER", "some_user")
+	defer os.Unsetenv("RALPH_CLI_TEST_USER")
+
+	cfg := &Config{
+		ManagementUserName:     "env:RALPH_CLI_TEST_USER",
+		ManagementUserPassword: "some_password",
+		Credentials: []Credential{
+			{Network: "10.20.0.0/16", ManagementUserPassword: "env:RALPH_CLI_TEST_SECRET"},
+			{Network: "10.0.0.0/8", ManagementUserName: "other_user", ManagementUserPassword: "other_password"},
+			{Script: "ilo.py", ManagementUserPassword: "env:RALPH_CLI_TEST_DOES_NOT_EXIST"},
+		},
+	}
+
+	var cases = map[string]struct {
+		addr         Addr
+		script       string
+		wantUser     string
+		wantPassword string
+		errMsg       string
+	}{
+		"#0 First matching credentials win, empty fields are inherited": {
+			addr:         "10.20.1.5",
+			wantUser:     "some_user",
+			wantPassword: "secret_from_env",
+		},
+		"#1 Second credentials": {
+			addr:         "10.30.1.5",
+			wantUser:     "other_user",
+			wantPassword: "other_password",
+		},
+		"#2 No matching credentials": {
+			addr:         "192.168.1.5",
+			wantUser:     "some_user",
+			wantPassword: "some_password",
+		},
+		"#3 Unresolvable secret": {
+			addr:   "192.168.1.5",
+			script: "ilo.py",
+			errMsg: "error resolving ManagementUserPassword from credentials #2",
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := cfg.ForHost(tc.addr, tc.script)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if got.ManagementUserName != tc.wantUser || got.ManagementUserPassword != tc.wantPassword {
+				t.Errorf("%s\n got: %s/%s\nwant: %s/%s", tn,
+					got.ManagementUserName, got.ManagementUserPassword, tc.wantUser, tc.wantPassword)
+			}
+		}
+	}
+	if cfg.ManagementUserName != "env:RALPH_CLI_TEST_USER" || cfg.ManagementUserPassword != "some_password" {
+		t.Errorf("original config has been modified: %+v", cfg)
+	}
+}
@@ -3,6 +3,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 // Diff represents a set of bulk changes to be made on the same Component
@@ -33,12 +39,16 @@ func (d Diff) IsEmpty() bool {
 // Ralph. It's main part is the Data field, holding JSON-ised payload that will
 // be send to Ralph. Other fields provide convenience shourtcuts for some
 // functions/methods (e.g. Component field holds a reference to the original
-// object, which frees us from unmarshaling contents of Data field).
+// object, which frees us from unmarshaling contents of Data field). For
+// updates, Old field holds the object as it is stored in Ralph (i.e. before
+// the update), which allows presenting changes field by field (see
+// FieldChanges and renderDiff).
 type DiffComponent struct {
 	ID        int       // ID of the object held in Component field
 	Name      string    // name of the component (e.g. Ethernet, Memory)
 	Data      []byte    // JSON-ed Component field
 	Component Component // reference to the original object
+	Old       Component // object before the update (only for updates)
 }
 
 // NewDiffComponent creates a DiffComponent based on a given component. Since
@@ -95,6 +105,42 @@ func NewDiffComponent(component Component) (*DiffComponent, error) {
 		}
 	case Disk:
 		return NewDiffComponent(&v)
+	case *PowerSupply:
+		id = v.ID
+		name = "PowerSupply"
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	case PowerSupply:
+		return NewDiffComponent(&v)
+	case *StorageController:
+		id = v.ID
+		name = "StorageController"
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	case StorageController:
+		return NewDiffComponent(&v)
+	case *GPU:
+		id = v.ID
+		name = "GPU"
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	case GPU:
+		return NewDiffComponent(&v)
+	case *NVMeDrive:
+		id = v.ID
+		name = "NVMeDrive"
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	case NVMeDrive:
+		return NewDiffComponent(&v)
 	case *DataCenterAsset:
 		id = *v.ID
 		name = "DataCenterAsset"
@@ -120,14 +166,191 @@ func (d DiffComponent) String() string {
 		d.ID, d.Name, string(d.Data), d.Component)
 }
 
+// FieldChange represents a change of a single field of some component. Field
+// is named in the same way as in JSON sent to Ralph (e.g. "firmware_version"),
+// and Old/New values are presented in their human-readable form (e.g. "10
+// Gbps" for EthSpeed, instead of its integer code).
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (f FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", f.Field, f.Old, f.New)
+}
+
+// FieldChanges compares Old and Component fields of a given DiffComponent and
+// returns changes detected between them. Fields being nil pointers in
+// Component are skipped, because they are not sent to Ralph (see e.g.
+// updateDataCenterAsset). When Old is not set (e.g. for DiffComponents meant
+// for creation or deletion), nil is returned.
+func (d DiffComponent) FieldChanges() []FieldChange {
+	if d.Old == nil || d.Component == nil {
+		return nil
+	}
+	oldVal := reflect.Indirect(reflect.ValueOf(d.Old))
+	newVal := reflect.Indirect(reflect.ValueOf(d.Component))
+	if oldVal.Type() != newVal.Type() || newVal.Kind() != reflect.Struct {
+		return nil
+	}
+	var changes []FieldChange
+	for i := 0; i < newVal.NumField(); i++ {
+		field := newVal.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch {
+		case field.PkgPath != "" || name == "-":
+			continue
+		case name == "":
+			name = field.Name
+		}
+		nv := newVal.Field(i)
+		if nv.Kind() == reflect.Ptr && nv.IsNil() {
+			continue
+		}
+		oldStr := formatFieldValue(oldVal.Field(i))
+		newStr := formatFieldValue(nv)
+		if oldStr != newStr {
+			changes = append(changes, FieldChange{name, oldStr, newStr})
+		}
+	}
+	return changes
+}
+
+// formatFieldValue is a helper function for FieldChanges, which presents v in
+// a human-readable form.
+func formatFieldValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "(none)"
+		}
+		v = v.Elem()
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		s := v.String()
+		if s == "" || strings.ContainsAny(s, "\r\n") {
+			return strconv.Quote(s)
+		}
+		return s
+	case v.Kind() == reflect.Struct && v.FieldByName("ID").IsValid():
+		// Nested objects (e.g. BaseObject) are presented by their IDs.
+		return fmt.Sprint(v.FieldByName("ID").Interface())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// ANSI escape sequences used by renderDiff for coloring its output (the same
+// colors are used by "git diff").
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+	colorReset = "\x1b[0m"
+)
+
+// renderDiff writes a human-readable presentation of diff to w: components to
+// be created are prefixed with "+", those to be deleted with "-", and
+// components to be updated with "~", followed by their changed fields (one per
+// line, as "field: old -> new"). If color is set to true, the output is
+// colored in the same way as "git diff" does it (see also isTerminal). It is
+// used for presenting changes planned in dry-run mode (see Plan.Write).
+func renderDiff(w io.Writer, diff *Diff, color bool) error {
+	paint := painter(color)
+	for _, d := range diff.Create {
+		if _, err := fmt.Fprintln(w, paint(colorGreen, fmt.Sprintf("+ %s", d.Component))); err != nil {
+			return err
+		}
+	}
+	for _, d := range diff.Update {
+		if _, err := fmt.Fprintln(w, paint(colorCyan, fmt.Sprintf("~ %s", d.Component))); err != nil {
+			return err
+		}
+		if err := renderFieldChanges(w, d, paint); err != nil {
+			return err
+		}
+	}
+	for _, d := range diff.Delete {
+		if _, err := fmt.Fprintln(w, paint(colorRed, fmt.Sprintf("- %s", d.Component))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderFieldChanges is a helper function for renderDiff and
+// reportDiffComponent, writing field-level changes of d to w (indented, one per
+// line).
+func renderFieldChanges(w io.Writer, d *DiffComponent, paint func(c, s string) string) error {
+	for _, f := range d.FieldChanges() {
+		_, err := fmt.Fprintf(w, "    %s: %s -> %s\n", f.Field, paint(colorRed, f.Old), paint(colorGreen, f.New))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportDiffComponent prints information about d being sent to Ralph with
+// a given method (msg describes the change, e.g. "created"). For updates,
+// changed fields are printed as well.
+func reportDiffComponent(d *DiffComponent, method, msg string, dryRun bool) {
+	switch {
+	case dryRun:
+		fmt.Printf("%s would be %s (dry-run).\n", d.Component, msg) // TODO(xor-xor): Use logger instead.
+	default:
+		fmt.Printf("%s %s successfully.\n", d.Component, msg) // TODO(xor-xor): Use logger instead.
+	}
+	if method == "PATCH" {
+		if err := renderFieldChanges(os.Stdout, d, painter(isTerminal(os.Stdout))); err != nil {
+			log.Printf("WARNING: Cannot print changed fields of %s: %s", d.Component, err)
+		}
+	}
+}
+
+// painter returns a function wrapping strings in a given ANSI color sequence,
+// or leaving them intact if color is false.
+func painter(color bool) func(c, s string) string {
+	return func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+}
+
+// isTerminal returns true if f is a terminal (e.g. when stdout is not
+// redirected to a file or piped to another process).
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// diffEndpoint returns Ralph's API endpoint, on which a given DiffComponent
+// should be sent with a given method.
+func diffEndpoint(d *DiffComponent, method string) string {
+	if method == "POST" {
+		return APIEndpoints[d.Name]
+	}
+	return fmt.Sprintf("%s/%d", APIEndpoints[d.Name], d.ID)
+}
+
 // SendDiffToRalph sends a given Diff to Ralph. If dryRun is set to true, then
-// no changes will be sent to Ralph. If noOutput is set to true, then all the
+// no changes will be sent to Ralph (see also Plan, for a machine-readable
+// presentation of such changes). If noOutput is set to true, then all the
 // output from this function will be silenced (this is mostly for tests).
 // Returned statusCodes slice is meant only to facilitate tests, so don't be
 // surprised if you see it ignored somewhere in the source code.
 func SendDiffToRalph(client *Client, diff *Diff, dryRun bool, noOutput bool) (statusCodes []int, err error) {
 
-	var send = func(d *DiffComponent, method, endpoint, msg string) (int, error) {
+	var send = func(d *DiffComponent, method, msg string) (int, error) {
 		var code int
 		var data []byte
 		switch {
@@ -137,45 +360,26 @@ func SendDiffToRalph(client *Client, diff *Diff, dryRun bool, noOutput bool) (st
 			data = d.Data
 		}
 		if !dryRun {
-			code, err = client.SendToRalph(method, endpoint, data)
+			code, err = client.SendToRalph(method, diffEndpoint(d, method), data)
 		}
 		if err != nil {
 			return code, err
 		}
 		if !noOutput {
-			fmt.Printf("%s %s successfully.\n", d.Component, msg) // TODO(xor-xor): Use logger instead.
+			reportDiffComponent(d, method, msg, dryRun)
 		}
 		return code, nil
 	}
 
-	for _, d := range diff.Create {
-		endpoint := APIEndpoints[d.Name]
-		code, err := send(d, "POST", endpoint, "created")
-		if err != nil {
-			return statusCodes, err
-		}
-		if code != 0 {
-			statusCodes = append(statusCodes, code)
-		}
-	}
-	for _, d := range diff.Update {
-		endpoint := fmt.Sprintf("%s/%d", APIEndpoints[d.Name], d.ID)
-		code, err := send(d, "PATCH", endpoint, "updated")
-		if err != nil {
-			return statusCodes, err
-		}
-		if code != 0 {
-			statusCodes = append(statusCodes, code)
-		}
-	}
-	for _, d := range diff.Delete {
-		endpoint := fmt.Sprintf("%s/%d", APIEndpoints[d.Name], d.ID)
-		code, err := send(d, "DELETE", endpoint, "deleted")
-		if err != nil {
-			return statusCodes, err
-		}
-		if code != 0 {
-			statusCodes = append(statusCodes, code)
+	for _, c := range diffCategories(diff) {
+		for _, d := range c.dcs {
+			code, err := send(d, c.method, c.msg)
+			if err != nil {
+				return statusCodes, err
+			}
+			if code != 0 {
+				statusCodes = append(statusCodes, code)
+			}
 		}
 	}
 	return statusCodes, nil
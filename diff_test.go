@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
@@ -223,6 +224,36 @@ func TestNewDiffComponent(t *testing.T) {
 		Slot:            1,
 		FirmwareVersion: "1.1.1",
 	}
+	psu := PowerSupply{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "PWR SPLY,495W,RDNT,DELTA",
+		Wattage:         495,
+		SerialNumber:    "CN1797255J0Q9J",
+		FirmwareVersion: "00.1D.7D",
+	}
+	ctrl := StorageController{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "PERC H730 Mini",
+		FirmwareVersion: "25.5.5.0005",
+		CacheSize:       1024,
+	}
+	gpu := GPU{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "NVIDIA Tesla V100",
+		SerialNumber:    "0323118012345",
+		FirmwareVersion: "88.00.43.00.03",
+	}
+	drive := NVMeDrive{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "Dell Express Flash PM1725a 1.6TB",
+		Size:            1490,
+		SerialNumber:    "S39XNX0J500123",
+		FirmwareVersion: "1.0.4",
+	}
 	dcAsset := DataCenterAsset{
 		ID:              PtrToInt(1),
 		FirmwareVersion: PtrToStr("1.1.1"),
@@ -265,7 +296,7 @@ func TestNewDiffComponent(t *testing.T) {
 			want: &DiffComponent{
 				ID:        1,
 				Name:      "Memory",
-				Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"speed":1600}`),
+				Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
 				Component: &memory,
 			},
 			errMsg: "",
@@ -275,7 +306,7 @@ func TestNewDiffComponent(t *testing.T) {
 			want: &DiffComponent{
 				ID:        1,
 				Name:      "Memory",
-				Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"speed":1600}`),
+				Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
 				Component: &memory,
 			},
 			errMsg: "",
@@ -305,7 +336,7 @@ func TestNewDiffComponent(t *testing.T) {
 			want: &DiffComponent{
 				ID:        1,
 				Name:      "Processor",
-				Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8}`),
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8,"socket":""}`),
 				Component: &proc,
 			},
 			errMsg: "",
@@ -315,7 +346,7 @@ func TestNewDiffComponent(t *testing.T) {
 			want: &DiffComponent{
 				ID:        1,
 				Name:      "Processor",
-				Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8}`),
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8,"socket":""}`),
 				Component: &proc,
 			},
 			errMsg: "",
@@ -361,6 +392,86 @@ func TestNewDiffComponent(t *testing.T) {
 			},
 			errMsg: "",
 		},
+		"#13 PowerSupply": {
+			component: psu,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "PowerSupply",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"PWR SPLY,495W,RDNT,DELTA","wattage":495,"serial_number":"CN1797255J0Q9J","firmware_version":"00.1D.7D"}`),
+				Component: &psu,
+			},
+			errMsg: "",
+		},
+		"#14 PowerSupply as a pointer": {
+			component: &psu,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "PowerSupply",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"PWR SPLY,495W,RDNT,DELTA","wattage":495,"serial_number":"CN1797255J0Q9J","firmware_version":"00.1D.7D"}`),
+				Component: &psu,
+			},
+			errMsg: "",
+		},
+		"#15 StorageController": {
+			component: ctrl,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "StorageController",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"PERC H730 Mini","firmware_version":"25.5.5.0005","cache_size":1024}`),
+				Component: &ctrl,
+			},
+			errMsg: "",
+		},
+		"#16 StorageController as a pointer": {
+			component: &ctrl,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "StorageController",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"PERC H730 Mini","firmware_version":"25.5.5.0005","cache_size":1024}`),
+				Component: &ctrl,
+			},
+			errMsg: "",
+		},
+		"#17 GPU": {
+			component: gpu,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "GPU",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"NVIDIA Tesla V100","serial_number":"0323118012345","firmware_version":"88.00.43.00.03"}`),
+				Component: &gpu,
+			},
+			errMsg: "",
+		},
+		"#18 GPU as a pointer": {
+			component: &gpu,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "GPU",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"NVIDIA Tesla V100","serial_number":"0323118012345","firmware_version":"88.00.43.00.03"}`),
+				Component: &gpu,
+			},
+			errMsg: "",
+		},
+		"#19 NVMeDrive": {
+			component: drive,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "NVMeDrive",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"Dell Express Flash PM1725a 1.6TB","size":1490,"serial_number":"S39XNX0J500123","firmware_version":"1.0.4"}`),
+				Component: &drive,
+			},
+			errMsg: "",
+		},
+		"#20 NVMeDrive as a pointer": {
+			component: &drive,
+			want: &DiffComponent{
+				ID:        1,
+				Name:      "NVMeDrive",
+				Data:      []byte(`{"id":1,"base_object":1,"model_name":"Dell Express Flash PM1725a 1.6TB","size":1490,"serial_number":"S39XNX0J500123","firmware_version":"1.0.4"}`),
+				Component: &drive,
+			},
+			errMsg: "",
+		},
 	}
 	for tn, tc := range cases {
 		got, err := NewDiffComponent(tc.component)
@@ -379,3 +490,100 @@ func TestNewDiffComponent(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldChanges(t *testing.T) {
+	var fw1, fw2, bios = "1.2", "1.4", "2.0"
+	var cases = map[string]struct {
+		dc   *DiffComponent
+		want []FieldChange
+	}{
+		"#0 Ethernet": {
+			&DiffComponent{
+				Component: &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "Intel(R) Ethernet", "10 Gbps", "1.4"},
+				Old:       &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "1 Gbps", "1.2"},
+			},
+			[]FieldChange{
+				{"model_name", `""`, "Intel(R) Ethernet"},
+				{"speed", "1 Gbps", "10 Gbps"},
+				{"firmware_version", "1.2", "1.4"},
+			},
+		},
+		"#1 DataCenterAsset with nil fields": {
+			&DiffComponent{
+				Component: &DataCenterAsset{FirmwareVersion: &fw2, BIOSVersion: &bios},
+				Old:       &DataCenterAsset{FirmwareVersion: &fw1},
+			},
+			[]FieldChange{
+				{"firmware_version", "1.2", "1.4"},
+				{"bios_version", "(none)", "2.0"},
+			},
+		},
+		"#2 No changes": {
+			&DiffComponent{
+				Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+				Old:       &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			},
+			nil,
+		},
+		"#3 Old component is missing": {
+			&DiffComponent{
+				Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			},
+			nil,
+		},
+	}
+	for tn, tc := range cases {
+		got := tc.dc.FieldChanges()
+		if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
+
+func TestRenderDiff(t *testing.T) {
+	diff := &Diff{
+		Create: []*DiffComponent{
+			&DiffComponent{Component: &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""}},
+		},
+		Update: []*DiffComponent{
+			&DiffComponent{
+				Component: &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "10 Gbps", "1.4"},
+				Old:       &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "1 Gbps", "1.2"},
+			},
+		},
+		Delete: []*DiffComponent{
+			&DiffComponent{Component: &Memory{3, BaseObject{1}, "Samsung DDR3 DIMM", 8192, 1600, ""}},
+		},
+	}
+	var cases = map[string]struct {
+		color bool
+		want  string
+	}{
+		"#0 Without colors": {
+			false,
+			`+ Memory{id: 0, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 16384, speed: 1600, slot: }
+~ Ethernet{id: 2, base_object_id: 1, mac: aa:bb:cc:dd:ee:ff, model_name: , speed: 10 Gbps, firmware_version: 1.4}
+    speed: 1 Gbps -> 10 Gbps
+    firmware_version: 1.2 -> 1.4
+- Memory{id: 3, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 8192, speed: 1600, slot: }
+`,
+		},
+		"#1 With colors": {
+			true,
+			"\x1b[32m+ Memory{id: 0, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 16384, speed: 1600, slot: }\x1b[0m\n" +
+				"\x1b[36m~ Ethernet{id: 2, base_object_id: 1, mac: aa:bb:cc:dd:ee:ff, model_name: , speed: 10 Gbps, firmware_version: 1.4}\x1b[0m\n" +
+				"    speed: \x1b[31m1 Gbps\x1b[0m -> \x1b[32m10 Gbps\x1b[0m\n" +
+				"    firmware_version: \x1b[31m1.2\x1b[0m -> \x1b[32m1.4\x1b[0m\n" +
+				"\x1b[31m- Memory{id: 3, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 8192, speed: 1600, slot: }\x1b[0m\n",
+		},
+	}
+	for tn, tc := range cases {
+		var buf bytes.Buffer
+		if err := renderDiff(&buf, diff, tc.color); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if got := buf.String(); got != tc.want {
+			t.Errorf("%s\n got: %q\nwant: %q", tn, got, tc.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"time"
 )
 
 // ValidationError is the type for aggregating errors that should be presented
@@ -47,3 +48,79 @@ func (e *ValidationError) Error() string {
 	}
 	return buf.String()
 }
+
+// ScanStage denotes a stage of the scan pipeline (see PerformScan) at which
+// ScanError has occurred.
+type ScanStage string
+
+// Stages of the scan pipeline, in the order in which they are performed.
+const (
+	StageScript ScanStage = "script" // running scan script and parsing its output
+	StageLookup ScanStage = "lookup" // fetching current state of a host from Ralph
+	StageDiff   ScanStage = "diff"   // comparing detected components with the ones from Ralph
+	StageSend   ScanStage = "send"   // sending detected changes to Ralph
+)
+
+// ScanError is the type for errors returned by PerformScan and its helpers
+// (e.g. updateEthernets, updateMemory). Apart from the original error, it tells
+// which host, component and stage of the scan pipeline it concerns, so the
+// caller may decide whether to continue with other hosts/components, retry or
+// abort.
+type ScanError struct {
+	Addr      Addr
+	Component string // e.g. "Ethernet", "Memory" (see APIEndpoints); empty if not applicable
+	Stage     ScanStage
+	Err       error
+}
+
+// NewScanError creates ScanError and returns it as error type.
+func NewScanError(addr Addr, component string, stage ScanStage, err error) error {
+	return &ScanError{
+		Addr:      addr,
+		Component: component,
+		Stage:     stage,
+		Err:       err,
+	}
+}
+
+// Error implements the error interface.
+func (e *ScanError) Error() string {
+	var component string
+	if e.Component != "" {
+		component = fmt.Sprintf(" (%s)", e.Component)
+	}
+	return fmt.Sprintf("scan of %s failed at %s stage%s: %s", e.Addr, e.Stage, component, e.Err)
+}
+
+// ScriptTimeoutError is returned by Script.Run when the script didn't finish
+// within its timeout (and had to be killed). Output holds everything that the
+// script has printed until then, which may help with finding out where it got
+// stuck.
+type ScriptTimeoutError struct {
+	Script  string
+	Timeout time.Duration
+	Output  []byte
+}
+
+// Error implements the error interface.
+func (e *ScriptTimeoutError) Error() string {
+	return fmt.Sprintf("script %s timed out after %s\noutput captured so far:\n-->\n%s<--",
+		e.Script, e.Timeout, e.Output)
+}
+
+// ScriptInterruptedError is returned by Script.Run when the script had to be
+// killed because ralph-cli has been interrupted (e.g. with Ctrl-C), or when it
+// wasn't started at all for the same reason.
+type ScriptInterruptedError struct {
+	Script string
+	Output []byte
+}
+
+// Error implements the error interface.
+func (e *ScriptInterruptedError) Error() string {
+	if len(e.Output) == 0 {
+		return fmt.Sprintf("script %s has been interrupted", e.Script)
+	}
+	return fmt.Sprintf("script %s has been interrupted\noutput captured so far:\n-->\n%s<--",
+		e.Script, e.Output)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -40,3 +41,35 @@ func TestValidationError(t *testing.T) {
 	}
 
 }
+
+func TestScanError(t *testing.T) {
+	var cases = map[string]struct {
+		addr      Addr
+		component string
+		stage     ScanStage
+		err       error
+		want      string
+	}{
+		"#0 Error concerning a given component": {
+			"10.20.30.40",
+			"Memory",
+			StageSend,
+			errors.New("something went wrong"),
+			"scan of 10.20.30.40 failed at send stage (Memory): something went wrong",
+		},
+		"#1 Error not related to any component": {
+			"10.20.30.40",
+			"",
+			StageScript,
+			errors.New("something went wrong"),
+			"scan of 10.20.30.40 failed at script stage: something went wrong",
+		},
+	}
+
+	for tn, tc := range cases {
+		got := NewScanError(tc.addr, tc.component, tc.stage, tc.err).Error()
+		if got != tc.want {
+			t.Errorf("%s\n got: %q\nwant: %q", tn, got, tc.want)
+		}
+	}
+}
@@ -86,11 +86,18 @@ func LoadFixture(dir, file string) (string, error) {
 // MockServerClient creates fake HTTP server and client, with code and body that should
 // be returned by server.
 func MockServerClient(code int, body string) (*httptest.Server, *Client) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return MockServerClientWithHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(code)
 		w.Header().Set("Content-Type", "application/json")
 		fmt.Fprintln(w, body)
 	}))
+}
+
+// MockServerClientWithHandler creates fake HTTP server and client, similarly
+// to MockServerClient, but server's responses are provided by handler (which is
+// useful when they should depend on request's method, path etc.).
+func MockServerClientWithHandler(handler http.Handler) (*httptest.Server, *Client) {
+	server := httptest.NewServer(handler)
 
 	transport := &http.Transport{
 		Proxy: func(req *http.Request) (*url.URL, error) {
@@ -173,3 +180,9 @@ func PtrToStr(s string) *string {
 func PtrToInt(i int) *int {
 	return &i
 }
+
+// PtrToBool returns a pointer to a bool b, which may be useful for
+// constructing struct literals with *bool fields.
+func PtrToBool(b bool) *bool {
+	return &b
+}
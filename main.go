@@ -1,19 +1,24 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/jawher/mow.cli"
 )
 
 func main() {
-	var w io.Writer
 	log.SetFlags(0)
 
 	cfgDir, err := GetCfgDirLocation("")
@@ -25,35 +30,77 @@ func main() {
 	if err != nil {
 		log.Fatalln(err)
 	}
-	cfg, err := GetConfig(filepath.Join(cfgDir, cfgFileName))
-	if err != nil {
-		log.Fatalln(err)
-	}
-	switch cfg.LogOutput {
-	// TODO(xor-xor): Uncomment this when logstash implementation will be ready.
-	// case "logstash":
-	// 	w = NewLogstashWriter(cfg)
-	default:
-		w = os.Stderr
-	}
-	log.SetOutput(w)
 
 	app := cli.App("ralph-cli", "Command-line interface for Ralph")
 
-	app.Command("scan", "Perform scan of a given host", func(cmd *cli.Cmd) {
-		addr := cmd.StringArg("IP_ADDR", "", "IP address of a host to scan")
+	cfgFile := app.StringOpt("config", "", fmt.Sprintf("Path to the config file (default: ~/.ralph-cli/config.toml, or %s env var)", ConfigPathEnvVar))
+	profile := app.StringOpt("profile", "", fmt.Sprintf("Profile from the config file to be used (default: DefaultProfile from config, or %s env var)", ProfileEnvVar))
+	ralphURL := app.StringOpt("ralph-url", "", "URL of Ralph's API (overrides RalphAPIURL from config)")
+	apiKey := app.StringOpt("api-key", "", "Ralph API key (overrides RalphAPIKey from config)")
+	timeout := app.IntOpt("timeout", 0, "Timeout for requests sent to Ralph, in seconds (overrides ClientTimeout from config)")
+
+	// Config is loaded after parsing the above switches, but before running
+	// any command.
+	var cfg *Config
+	app.Before = func() {
+		var flags = make(map[string]string)
+		if *ralphURL != "" {
+			flags["RalphAPIURL"] = *ralphURL
+		}
+		if *apiKey != "" {
+			flags["RalphAPIKey"] = *apiKey
+		}
+		if *timeout != 0 {
+			flags["ClientTimeout"] = strconv.Itoa(*timeout)
+		}
+		var err error
+		cfg, err = GetConfig(GetConfigPath(*cfgFile, cfgDir, cfgFileName), *profile, flags)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		var w io.Writer
+		switch cfg.LogOutput {
+		// TODO(xor-xor): Uncomment this when logstash implementation will be ready.
+		// case "logstash":
+		// 	w = NewLogstashWriter(cfg)
+		default:
+			w = os.Stderr
+		}
+		log.SetOutput(w)
+	}
+
+	app.Command("scan", "Perform scan of a given host(s)", func(cmd *cli.Cmd) {
+		addrsRaw := cmd.StringsArg("IP_ADDR", nil, "IP address(es) of host(s) to scan - networks in CIDR notation (e.g. 10.20.0.0/24) are also accepted")
+		hostsFile := cmd.StringOpt("hosts-file", "", "File with hosts to scan (one IP address or network per line)")
+		workers := cmd.IntOpt("workers", 1, "Number of hosts to be scanned concurrently")
 		script := cmd.StringOpt("script", "", "Script to be executed")
-		componentsRaw := cmd.StringOpt("components", "none", "Components to discover - possible values: none | all | eth,mem,fcc,cpu,disk")
+		fromFile := cmd.StringOpt("from-file", "", "Don't run any script, use ready-made JSON result from a given file instead")
+		fromStdin := cmd.BoolOpt("from-stdin", false, "Don't run any script, use ready-made JSON result from stdin instead")
+		componentsRaw := cmd.StringOpt("components", "none", "Components to discover - possible values: none | all | eth,mem,fcc,cpu,disk,psu,raid,gpu,nvme (psu, raid, gpu and nvme are not included in \"all\" - they require Ralph exposing power-supplies, storage-controllers, gpus and nvme-drives API endpoints, and fail with 404 otherwise)")
 		withBIOSAndFirmware := cmd.BoolOpt("with-bios-and-firmware", false, "Try to discover BIOS and firmware versions")
 		withModel := cmd.BoolOpt("with-model", false, "Append detected model name to \"Remarks\" field in Ralph")
+		bulk := cmd.BoolOpt("bulk", false, "Save all changes detected on a given host at once (if any of them fails, none is saved)")
+		scriptTimeout := cmd.IntOpt("script-timeout", 0, "Kill the script when it runs longer than a given number of seconds (overrides Timeout from script's manifest)")
 		dryRun := cmd.BoolOpt("dry-run", false, "Don't save anything in Ralph")
+		output := cmd.StringOpt("output", "", fmt.Sprintf("Print changes planned in dry-run mode in a given format - possible values: %s", strings.Join(PlanFormats, " | ")))
+		record := cmd.StringOpt("record", "", "Save script's output and Ralph's responses for each scanned host in a given dir (for use with '--replay')")
+		replay := cmd.StringOpt("replay", "", "Don't run any script nor talk to Ralph, replay scans recorded with '--record' in a given dir instead")
 
-		cmd.Spec = "IP_ADDR --script=<script name> [--components=<comma-separated list of components>] [--with-bios-and-firmware] [--with-model] [--dry-run]"
+		cmd.Spec = "[IP_ADDR...] [--hosts-file=<path>] [--workers=<number>] (--script=<script name> [--script-timeout=<seconds>] [--record=<dir>] | --from-file=<path> | --from-stdin | --replay=<dir>) [--components=<comma-separated list of components>] [--with-bios-and-firmware] [--with-model] [--bulk] [--dry-run [--output=<format>]]"
 
 		cmd.Action = func() {
-			if *script == "" {
+			if *script == "" && *fromFile == "" && !*fromStdin && *replay == "" {
 				log.Fatalln("No script supplied to '--script' switch. Aborting.")
 			}
+			if *workers < 1 {
+				log.Fatalln("Number of workers given to '--workers' switch should be greater than 0. Aborting.")
+			}
+			if *scriptTimeout < 0 {
+				log.Fatalln("Number of seconds given to '--script-timeout' switch should not be negative. Aborting.")
+			}
+			if *output != "" && !isPlanFormat(*output) {
+				log.Fatalf("Unknown format given to '--output' switch: %s. Aborting.", *output)
+			}
 			// TODO(xor-xor): Consider adding some message when no --components
 			// *and* --with-bios-and-firmware *and* --with-model is given, or
 			// make at least one of them required.
@@ -61,16 +108,278 @@ func main() {
 			if err != nil {
 				log.Fatalf("Error parsing value(s) for '--component' switch: %s. Aborting.", err)
 			}
-			if changesDetected := PerformScan(*addr, *script, *components, *withBIOSAndFirmware, *withModel, *dryRun, cfg, cfgDir); !changesDetected {
-				log.Println("No changes detected.")
+			var addrs []Addr
+			if *replay != "" && len(*addrsRaw) == 0 && *hostsFile == "" {
+				addrs, err = RecordedHosts(*replay)
+			} else {
+				addrs, err = getAddrsToScan(*addrsRaw, *hostsFile)
+			}
+			if err != nil {
+				log.Fatalf("%s. Aborting.", err)
+			}
+			opts := &ScanOpts{
+				Components:          *components,
+				WithBIOSAndFirmware: *withBIOSAndFirmware,
+				WithModel:           *withModel,
+				DryRun:              *dryRun,
+				Bulk:                *bulk,
+				RecordDir:           *record,
+			}
+			switch {
+			case *replay != "":
+				// Components etc. are taken from the recording (see ReplayScan).
+			case *fromFile != "" || *fromStdin:
+				if len(addrs) > 1 {
+					log.Fatalln("Ready-made scan result can be used only with a single host. Aborting.")
+				}
+				opts.Result, err = readScanResult(*fromFile)
+				if err != nil {
+					log.Fatalln(err)
+				}
+			default:
+				s, err := PrepareScript(*script, cfgDir)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				if *scriptTimeout > 0 {
+					s.Timeout = time.Duration(*scriptTimeout) * time.Second
+				}
+				opts.Script = &s
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				cancelOnInterrupt(cancel)
+				opts.Context = ctx
+			}
+			switch {
+			case *dryRun && *output != "":
+				// Stdout is reserved for the plan in such case.
+				log.Println("INFO: Running in dry-run mode, no changes will be saved in Ralph.")
+				opts.Plan = NewPlan(addrs)
+			case *dryRun:
+				// TODO(xor-xor): Wire up logger here.
+				fmt.Println("INFO: Running in dry-run mode, no changes will be saved in Ralph.")
+			}
+			results := ScanHosts(addrs, *workers, func(addr Addr) (bool, error) {
+				if *replay != "" {
+					return ReplayScan(addr, filepath.Join(*replay, string(addr)), opts, cfg)
+				}
+				return PerformScan(addr, opts, cfg)
+			})
+			if opts.Plan != nil {
+				if err := opts.Plan.Write(os.Stdout, *output); err != nil {
+					log.Fatalln(err)
+				}
+			}
+			var failed int
+			for _, r := range results {
+				var prefix string
+				if len(results) > 1 {
+					prefix = fmt.Sprintf("%s: ", r.Addr)
+				}
+				switch {
+				case r.Err != nil:
+					// ScanError already includes the address of a scanned host.
+					failed++
+					log.Println(r.Err)
+				case !r.ChangesDetected:
+					log.Printf("%sNo changes detected.", prefix)
+				}
+			}
+			if failed > 0 {
+				log.Fatalf("Scan failed for %d out of %d host(s).", failed, len(results))
 			}
 		}
 	})
 
+	app.Command("script", "Manage scan scripts", func(cmd *cli.Cmd) {
+		cmd.Command("list", "List available scripts", func(cmd *cli.Cmd) {
+			cmd.Action = func() {
+				scripts, err := ListScripts(cfgDir)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				if err := WriteScriptList(os.Stdout, scripts); err != nil {
+					log.Fatalln(err)
+				}
+			}
+		})
+		cmd.Command("show", "Show details of a given script (manifest, virtualenv etc.)", func(cmd *cli.Cmd) {
+			name := cmd.StringArg("NAME", "", "Name of the script (e.g. idrac.py)")
+			cmd.Action = func() {
+				si, err := GetScriptInfo(*name, cfgDir)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				if err := WriteScriptDetails(os.Stdout, si); err != nil {
+					log.Fatalln(err)
+				}
+			}
+		})
+		cmd.Command("new", "Create a new script (with manifest) from a template", func(cmd *cli.Cmd) {
+			name := cmd.StringArg("NAME", "", "Name of the script (extension appropriate for the language is appended if missing)")
+			lang := cmd.StringOpt("lang", "python", fmt.Sprintf("Language of the script - possible values: %s", strings.Join(ScriptLanguages(), " | ")))
+			cmd.Spec = "NAME [--lang=<language>]"
+			cmd.Action = func() {
+				si, err := CreateScript(*name, *lang, cfgDir)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				fmt.Printf("Script %s created (manifest: %s).\n", si.Path, si.Manifest.Path)
+			}
+		})
+		cmd.Command("restore", "Restore a bundled script (with manifest)", func(cmd *cli.Cmd) {
+			name := cmd.StringArg("NAME", "", fmt.Sprintf("Name of the script - possible values: %s", strings.Join(bundledScripts(), " | ")))
+			force := cmd.BoolOpt("force", false, "Overwrite existing files")
+			cmd.Spec = "NAME [--force]"
+			cmd.Action = func() {
+				paths, err := RestoreScript(*name, cfgDir, *force)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				for _, p := range paths {
+					fmt.Printf("Restored %s.\n", p)
+				}
+			}
+		})
+		cmd.Command("venv", "Create or refresh virtualenv of a given Python script", func(cmd *cli.Cmd) {
+			name := cmd.StringArg("NAME", "", "Name of the script (e.g. idrac.py)")
+			rebuild := cmd.BoolOpt("rebuild", false, "Recreate virtualenv from scratch, even if it's up to date")
+			cmd.Spec = "[--rebuild] NAME"
+			cmd.Action = func() {
+				s, err := NewScript(*name, cfgDir)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				if s.Manifest == nil || s.Manifest.Language != "python" {
+					log.Fatalf("Script %s doesn't need virtualenv (it's not a Python script with a manifest). Aborting.", *name)
+				}
+				status, err := PrepareVenv(s, *rebuild)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				fmt.Printf("Virtualenv %s %s.\n", MakeVenvPath(s), status)
+			}
+		})
+		cmd.Command("validate", "Check if output of a given script conforms to scripts contract (JSON Schema)", func(cmd *cli.Cmd) {
+			name := cmd.StringArg("NAME", "", "Name of the script (e.g. idrac.py)")
+			output := cmd.StringOpt("output", "", "File holding output of the script (in JSON)")
+			cmd.Spec = "NAME --output=<path>"
+			cmd.Action = func() {
+				if _, err := GetScriptInfo(*name, cfgDir); err != nil {
+					log.Fatalln(err)
+				}
+				data, err := ioutil.ReadFile(*output)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				violations, err := ValidateScanResult(data)
+				if err != nil {
+					log.Fatalf("Output of script %s in %s is not valid: %s", *name, *output, err)
+				}
+				for _, v := range violations {
+					fmt.Println(v)
+				}
+				if len(violations) > 0 {
+					log.Fatalf("Output of script %s in %s doesn't conform to scripts contract (schema v%d): %d error(s) found.",
+						*name, *output, ContractVersion, len(violations))
+				}
+				fmt.Printf("Output of script %s in %s conforms to scripts contract (schema v%d).\n",
+					*name, *output, ContractVersion)
+			}
+		})
+		cmd.Command("remove", "Remove a script along with its manifest and virtualenv", func(cmd *cli.Cmd) {
+			name := cmd.StringArg("NAME", "", "Name of the script (e.g. idrac.py)")
+			cmd.Action = func() {
+				paths, err := RemoveScript(*name, cfgDir)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				for _, p := range paths {
+					fmt.Printf("Removed %s.\n", p)
+				}
+			}
+		})
+	})
+
 	app.Version("v version", "0.3.0")
 	app.Run(os.Args)
 }
 
+// cancelOnInterrupt calls cancel when ralph-cli receives SIGINT (e.g. on
+// Ctrl-C) or SIGTERM, so running scan scripts can be terminated cleanly (see
+// Script.Run). Another such signal terminates ralph-cli immediately.
+func cancelOnInterrupt(cancel context.CancelFunc) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		signal.Stop(sigs)
+		log.Println("Interrupted, terminating running scripts (press Ctrl-C again to exit immediately)...")
+		cancel()
+	}()
+}
+
+// getAddrsToScan collects hosts given as IP_ADDR args and in a file given to
+// --hosts-file switch, and expands them (see NewAddrs) to a single list of
+// Addrs. Duplicates are removed, while preserving the original order.
+func getAddrsToScan(addrsRaw []string, hostsFile string) ([]Addr, error) {
+	hosts := addrsRaw
+	if hostsFile != "" {
+		f, err := os.Open(hostsFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		hh, err := ReadHostsFile(f)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, hh...)
+	}
+	if len(hosts) == 0 {
+		return nil, errors.New("no hosts to scan (use IP_ADDR args and/or '--hosts-file' switch)")
+	}
+	var addrs []Addr
+	seen := make(map[Addr]bool)
+	for _, h := range hosts {
+		aa, err := NewAddrs(h)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host %q: %s", h, err)
+		}
+		for _, a := range aa {
+			if !seen[a] {
+				seen[a] = true
+				addrs = append(addrs, a)
+			}
+		}
+	}
+	return addrs, nil
+}
+
+// readScanResult reads a ready-made scan result from a file given as path, or
+// from stdin, when path is an empty string.
+func readScanResult(path string) (*ScanResult, error) {
+	if path == "" {
+		return ReadScanResult(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadScanResult(f)
+}
+
+// isPlanFormat returns true if format is one of PlanFormats.
+func isPlanFormat(format string) bool {
+	for _, f := range PlanFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // parseComponents returns a map denoting presence or absence of a given
 // component in --components=<...> switch. Aborts the program when an unknown
 // component is found.
@@ -83,6 +392,10 @@ func parseComponents(componentsRaw string) (*map[string]bool, error) {
 		"fcc":  false,
 		"cpu":  false,
 		"disk": false,
+		"psu":  false,
+		"raid": false,
+		"gpu":  false,
+		"nvme": false,
 	}
 	cc := strings.Split(componentsRaw, ",")
 	for _, c := range cc {
@@ -94,8 +407,12 @@ func parseComponents(componentsRaw string) (*map[string]bool, error) {
 	if components["none"] == true && len(cc) > 1 {
 		return nil, errors.New("invalid combination: \"none\" option should be used exclusively")
 	}
-	if components["all"] == true && len(cc) > 1 {
-		return nil, errors.New("invalid combination: \"all\" option should be used exclusively")
+	if components["all"] == true {
+		for _, c := range cc {
+			if c != "all" && !optInComponents[c] {
+				return nil, errors.New("invalid combination: \"all\" option can be combined only with psu, raid, gpu and nvme")
+			}
+		}
 	}
 	return &components, nil
 }
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -13,7 +16,7 @@ func TestParseComponents(t *testing.T) {
 		want       *map[string]bool
 	}{
 		"#0 All valid components": {
-			"eth,mem,fcc,cpu,disk",
+			"eth,mem,fcc,cpu,disk,psu,raid,gpu,nvme",
 			"",
 			&map[string]bool{
 				"none": false,
@@ -23,6 +26,10 @@ func TestParseComponents(t *testing.T) {
 				"fcc":  true,
 				"cpu":  true,
 				"disk": true,
+				"psu":  true,
+				"raid": true,
+				"gpu":  true,
+				"nvme": true,
 			},
 		},
 		"#1 Unknown component": {
@@ -35,11 +42,28 @@ func TestParseComponents(t *testing.T) {
 			"invalid combination",
 			nil,
 		},
-		"#3 \"all\" option should be used exclusively": {
+		"#3 \"all\" option can't be combined with components it includes": {
 			"all,eth,mem",
 			"invalid combination",
 			nil,
 		},
+		"#4 \"all\" option combined with opt-in components": {
+			"all,psu,nvme",
+			"",
+			&map[string]bool{
+				"none": false,
+				"all":  true,
+				"eth":  false,
+				"mem":  false,
+				"fcc":  false,
+				"cpu":  false,
+				"disk": false,
+				"psu":  true,
+				"raid": false,
+				"gpu":  false,
+				"nvme": true,
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -59,3 +83,56 @@ func TestParseComponents(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAddrsToScan(t *testing.T) {
+	hostsFile, err := ioutil.TempFile("", "ralph-cli-tests-")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(hostsFile.Name())
+	fmt.Fprintln(hostsFile, "# some comment\n10.20.30.40\n10.20.31.0/30")
+	hostsFile.Close()
+
+	var cases = map[string]struct {
+		addrsRaw  []string
+		hostsFile string
+		errMsg    string
+		want      []Addr
+	}{
+		"#0 Args and hosts file should be merged w/o duplicates": {
+			[]string{"10.20.30.40", "10.20.30.41"},
+			hostsFile.Name(),
+			"",
+			[]Addr{"10.20.30.40", "10.20.30.41", "10.20.31.1", "10.20.31.2"},
+		},
+		"#1 No hosts": {
+			nil,
+			"",
+			"no hosts to scan",
+			nil,
+		},
+		"#2 Invalid host": {
+			[]string{"10.20.30.40/99"},
+			"",
+			"invalid host",
+			nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := getAddrsToScan(tc.addrsRaw, tc.hostsFile)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
+			}
+		}
+	}
+}
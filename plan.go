@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PlanFormats lists formats in which Plan can be written (see Plan.Write).
+var PlanFormats = []string{"json", "yaml", "table", "diff"}
+
+// Plan holds all the changes that scan would send to Ralph, grouped by scanned
+// hosts and component types. It is meant for dry-run mode, where it replaces
+// the actual sending of changes, so it can be reviewed (or attached to some
+// ticket etc.) before running the real scan. Plan is safe for concurrent use.
+type Plan struct {
+	mu     sync.Mutex
+	hosts  []*HostPlan
+	byAddr map[Addr]*HostPlan
+}
+
+// HostPlan holds changes planned for a single host, keyed by component name
+// (e.g. Ethernet, Memory - see APIEndpoints).
+type HostPlan struct {
+	Host    Addr                      `json:"host" yaml:"host"`
+	Changes map[string]*ComponentPlan `json:"changes" yaml:"changes"`
+	diff    Diff                      // all the changes, for the "diff" format
+}
+
+// ComponentPlan holds changes planned for a single component type, mirroring
+// the structure of Diff.
+type ComponentPlan struct {
+	Create []*PlanEntry `json:"create,omitempty" yaml:"create,omitempty"`
+	Update []*PlanEntry `json:"update,omitempty" yaml:"update,omitempty"`
+	Delete []*PlanEntry `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// PlanEntry represents a single request that would be sent to Ralph. Its
+// Endpoint is the URL to which the request would be sent (see
+// Client.endpointURL).
+type PlanEntry struct {
+	Method   string      `json:"method" yaml:"method"`
+	Endpoint string      `json:"endpoint" yaml:"endpoint"`
+	Payload  interface{} `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// NewPlan creates an empty Plan for given addrs. Hosts will be presented in
+// the same order as in addrs (even those for which there are no changes).
+func NewPlan(addrs []Addr) *Plan {
+	p := &Plan{byAddr: make(map[Addr]*HostPlan)}
+	for _, addr := range addrs {
+		p.host(addr)
+	}
+	return p
+}
+
+// host is a helper method returning HostPlan for addr (and creating it, if
+// needed). It should be called with p.mu held (or from NewPlan).
+func (p *Plan) host(addr Addr) *HostPlan {
+	if hp, ok := p.byAddr[addr]; ok {
+		return hp
+	}
+	hp := &HostPlan{Host: addr, Changes: make(map[string]*ComponentPlan)}
+	p.byAddr[addr] = hp
+	p.hosts = append(p.hosts, hp)
+	return hp
+}
+
+// Add records changes from diff as planned for the host scanned with client
+// (see Client.scannedAddr), which would send them to Ralph.
+func (p *Plan) Add(client *Client, diff *Diff) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hp := p.host(client.scannedAddr)
+	hp.diff.Create = append(hp.diff.Create, diff.Create...)
+	hp.diff.Update = append(hp.diff.Update, diff.Update...)
+	hp.diff.Delete = append(hp.diff.Delete, diff.Delete...)
+	for _, c := range diffCategories(diff) {
+		for _, d := range c.dcs {
+			entry := &PlanEntry{
+				Method:   c.method,
+				Endpoint: client.endpointURL(diffEndpoint(d, c.method)),
+			}
+			if c.method != "DELETE" {
+				if err := json.Unmarshal(d.Data, &entry.Payload); err != nil {
+					return fmt.Errorf("error unmarshaling payload for %s: %v", d.Name, err)
+				}
+			}
+			cp, ok := hp.Changes[d.Name]
+			if !ok {
+				cp = &ComponentPlan{}
+				hp.Changes[d.Name] = cp
+			}
+			switch c.method {
+			case "POST":
+				cp.Create = append(cp.Create, entry)
+			case "PATCH":
+				cp.Update = append(cp.Update, entry)
+			case "DELETE":
+				cp.Delete = append(cp.Delete, entry)
+			}
+		}
+	}
+	return nil
+}
+
+// Write writes Plan to w in a given format (see PlanFormats). The "diff" format
+// is meant for humans rather than machines - it is colored when w is
+// a terminal (see renderDiff).
+func (p *Plan) Write(w io.Writer, format string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(p.hosts, "", "    ")
+		if err != nil {
+			return fmt.Errorf("error marshaling plan: %v", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(p.hosts)
+		if err != nil {
+			return fmt.Errorf("error marshaling plan: %v", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case "table":
+		return p.writeTable(w)
+	case "diff":
+		f, ok := w.(*os.File)
+		return p.writeDiff(w, ok && isTerminal(f))
+	default:
+		return fmt.Errorf("unknown plan format: %s", format)
+	}
+}
+
+// writeTable is a helper method for Plan.Write.
+func (p *Plan) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "HOST\tCOMPONENT\tACTION\tMETHOD\tENDPOINT\tPAYLOAD")
+	for _, hp := range p.hosts {
+		var names []string
+		for name := range hp.Changes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cp := hp.Changes[name]
+			var actions = []struct {
+				action  string
+				entries []*PlanEntry
+			}{
+				{"create", cp.Create},
+				{"update", cp.Update},
+				{"delete", cp.Delete},
+			}
+			for _, a := range actions {
+				for _, e := range a.entries {
+					payload := []byte("-")
+					if e.Payload != nil {
+						var err error
+						if payload, err = json.Marshal(e.Payload); err != nil {
+							return fmt.Errorf("error marshaling payload: %v", err)
+						}
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+						hp.Host, name, a.action, e.Method, e.Endpoint, payload)
+				}
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// writeDiff is a helper method for Plan.Write, presenting changes planned for
+// each host field by field (see renderDiff).
+func (p *Plan) writeDiff(w io.Writer, color bool) error {
+	for _, hp := range p.hosts {
+		if _, err := fmt.Fprintf(w, "%s:\n", hp.Host); err != nil {
+			return err
+		}
+		if hp.diff.IsEmpty() {
+			if _, err := fmt.Fprintln(w, "No changes detected."); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := renderDiff(w, &hp.diff, color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
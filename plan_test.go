@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPlanWrite(t *testing.T) {
+	diff := &Diff{
+		Create: []*DiffComponent{
+			&DiffComponent{
+				ID:        0,
+				Name:      "Memory",
+				Data:      []byte(`{"base_object":1,"id":0,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
+				Component: &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			},
+		},
+		Update: []*DiffComponent{
+			&DiffComponent{
+				ID:        2,
+				Name:      "Ethernet",
+				Data:      []byte(`{"id":2,"base_object":1,"mac":"aa:bb:cc:dd:ee:ff","model_name":"","speed":4,"firmware_version":"1.4"}`),
+				Component: &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "10 Gbps", "1.4"},
+				Old:       &Ethernet{2, BaseObject{1}, macs["aa:bb:cc:dd:ee:ff"], "", "10 Gbps", "1.2"},
+			},
+		},
+		Delete: []*DiffComponent{
+			&DiffComponent{
+				ID:        3,
+				Name:      "Memory",
+				Data:      []byte(`{"base_object":1,"id":3,"model_name":"Samsung DDR3 DIMM","size":8192,"slot":"","speed":1600}`),
+				Component: &Memory{3, BaseObject{1}, "Samsung DDR3 DIMM", 8192, 1600, ""},
+			},
+		},
+	}
+	plan := NewPlan([]Addr{"10.20.30.40", "10.20.30.41"})
+	client := &Client{scannedAddr: "10.20.30.40", ralphURL: "http://ralph.local/api"}
+	if err := plan.Add(client, diff); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var cases = map[string]struct {
+		format string
+		errMsg string
+		want   string
+	}{
+		"#0 JSON": {
+			"json",
+			"",
+			`[
+    {
+        "host": "10.20.30.40",
+        "changes": {
+            "Ethernet": {
+                "update": [
+                    {
+                        "method": "PATCH",
+                        "endpoint": "http://ralph.local/api/ethernets/2/",
+                        "payload": {
+                            "base_object": 1,
+                            "firmware_version": "1.4",
+                            "id": 2,
+                            "mac": "aa:bb:cc:dd:ee:ff",
+                            "model_name": "",
+                            "speed": 4
+                        }
+                    }
+                ]
+            },
+            "Memory": {
+                "create": [
+                    {
+                        "method": "POST",
+                        "endpoint": "http://ralph.local/api/memory/",
+                        "payload": {
+                            "base_object": 1,
+                            "id": 0,
+                            "model_name": "Samsung DDR3 DIMM",
+                            "size": 16384,
+                            "slot": "",
+                            "speed": 1600
+                        }
+                    }
+                ],
+                "delete": [
+                    {
+                        "method": "DELETE",
+                        "endpoint": "http://ralph.local/api/memory/3/"
+                    }
+                ]
+            }
+        }
+    },
+    {
+        "host": "10.20.30.41",
+        "changes": {}
+    }
+]
+`,
+		},
+		"#1 YAML": {
+			"yaml",
+			"",
+			`- host: 10.20.30.40
+  changes:
+    Ethernet:
+      update:
+      - method: PATCH
+        endpoint: http://ralph.local/api/ethernets/2/
+        payload:
+          base_object: 1
+          firmware_version: "1.4"
+          id: 2
+          mac: aa:bb:cc:dd:ee:ff
+          model_name: ""
+          speed: 4
+    Memory:
+      create:
+      - method: POST
+        endpoint: http://ralph.local/api/memory/
+        payload:
+          base_object: 1
+          id: 0
+          model_name: Samsung DDR3 DIMM
+          size: 16384
+          slot: ""
+          speed: 1600
+      delete:
+      - method: DELETE
+        endpoint: http://ralph.local/api/memory/3/
+- host: 10.20.30.41
+  changes: {}
+`,
+		},
+		"#2 Table": {
+			"table",
+			"",
+			`HOST         COMPONENT  ACTION  METHOD  ENDPOINT                             PAYLOAD
+10.20.30.40  Ethernet   update  PATCH   http://ralph.local/api/ethernets/2/  {"base_object":1,"firmware_version":"1.4","id":2,"mac":"aa:bb:cc:dd:ee:ff","model_name":"","speed":4}
+10.20.30.40  Memory     create  POST    http://ralph.local/api/memory/       {"base_object":1,"id":0,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}
+10.20.30.40  Memory     delete  DELETE  http://ralph.local/api/memory/3/     -
+`,
+		},
+		"#3 Diff": {
+			"diff",
+			"",
+			`10.20.30.40:
++ Memory{id: 0, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 16384, speed: 1600, slot: }
+~ Ethernet{id: 2, base_object_id: 1, mac: aa:bb:cc:dd:ee:ff, model_name: , speed: 10 Gbps, firmware_version: 1.4}
+    firmware_version: 1.2 -> 1.4
+- Memory{id: 3, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 8192, speed: 1600, slot: }
+10.20.30.41:
+No changes detected.
+`,
+		},
+		"#4 Unknown format": {
+			"xml",
+			"unknown plan format",
+			"",
+		},
+	}
+
+	for tn, tc := range cases {
+		var buf bytes.Buffer
+		err := plan.Write(&buf, tc.format)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("%s\n got: %s\nwant: %s", tn, got, tc.want)
+			}
+		}
+	}
+}
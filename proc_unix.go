@@ -0,0 +1,23 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup makes cmd a leader of a new process group, so it can be
+// killed along with all of its children (see killProcessGroup). Apart from
+// that, signals sent from the terminal (e.g. Ctrl-C) won't reach cmd directly,
+// so it's up to ralph-cli to terminate it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the process group of cmd (which should be started
+// after calling setProcessGroup on it).
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
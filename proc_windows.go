@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroup makes cmd a root of a new process group, so signals sent
+// from the console (e.g. Ctrl-C) won't reach cmd directly, and it's up to
+// ralph-cli to terminate it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killProcessGroup kills cmd along with all of its children. There's no such
+// thing as killing a process group on Windows, so the whole process tree is
+// killed with taskkill instead (otherwise, children holding the output pipes
+// of cmd would keep ralph-cli waiting for them). When taskkill fails, only cmd
+// is killed.
+func killProcessGroup(cmd *exec.Cmd) error {
+	pid := strconv.Itoa(cmd.Process.Pid)
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", pid).Run(); err != nil {
+		return cmd.Process.Kill()
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 )
@@ -12,8 +14,8 @@ import (
 type Addr string
 
 // NewAddr creates a new Addr from a given string and performs some basic validation on it.
+// Networks in CIDR notation are handled by NewAddrs.
 func NewAddr(s string) (Addr, error) {
-	// TODO(xor-xor): add support for net.IPNet (via net.ParseCIDR etc.)
 	_, err := net.LookupIP(s)
 	if err != nil {
 		return "", err
@@ -22,37 +24,98 @@ func NewAddr(s string) (Addr, error) {
 	return Addr(s), nil
 }
 
+// maxNetworkSize limits the number of addresses that a single network given in
+// CIDR notation may be expanded to (that's a /16 for IPv4).
+const maxNetworkSize = 1 << 16
+
+// NewAddrs creates a list of Addrs from a given string, which may be an IP
+// address, an FQDN or a network in CIDR notation (e.g. "10.20.0.0/24"). In the
+// latter case, all the addresses from such network are returned, except for
+// the network and broadcast ones (unless it's a /31 or /32 network, where there
+// are no such addresses).
+func NewAddrs(s string) ([]Addr, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := NewAddr(s)
+		if err != nil {
+			return nil, err
+		}
+		return []Addr{addr}, nil
+	}
+	ip, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones > 16 {
+		return nil, fmt.Errorf("network %s is too large (more than %d addresses)", s, maxNetworkSize)
+	}
+	var addrs []Addr
+	for ip = ip.Mask(ipNet.Mask); ipNet.Contains(ip); ip = nextIP(ip) {
+		addrs = append(addrs, Addr(ip.String()))
+	}
+	if ip.To4() != nil && bits-ones > 1 {
+		addrs = addrs[1 : len(addrs)-1]
+	}
+	return addrs, nil
+}
+
+// nextIP is a helper function for NewAddrs. It returns a copy of ip incremented
+// by one.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// ReadHostsFile reads a list of hosts to scan from r. Each line should hold a
+// single IP address, FQDN or network in CIDR notation (i.e., anything that
+// NewAddrs accepts). Empty lines and lines starting with "#" are skipped.
+func ReadHostsFile(r io.Reader) ([]string, error) {
+	var hosts []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hosts = append(hosts, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading hosts file: %v", err)
+	}
+	return hosts, nil
+}
+
 // GetBaseObject fetches BaseObjects associated with given Addr.
 func (a Addr) GetBaseObject(c *Client) (*BaseObject, error) {
 	q := fmt.Sprintf("ip=%s", a)
-	rawBody, err := c.GetFromRalph(APIEndpoints["BaseObject"], q)
+	rawBody, err := c.GetAllFromRalph(APIEndpoints["BaseObject"], q)
 	if err != nil {
 		return nil, err
 	}
 
-	var baseObjs BaseObjectList
+	var baseObjs []BaseObject
 	if err := json.Unmarshal(rawBody, &baseObjs); err != nil {
 		return nil, fmt.Errorf("error unmarshaling base object: %v", err)
 	}
 
 	switch {
-	case baseObjs.Count == 0:
+	case len(baseObjs) == 0:
 		return nil, fmt.Errorf("IP address %s doesn't have any base objects", a)
-	case baseObjs.Count > 1:
+	case len(baseObjs) > 1:
 		return nil, fmt.Errorf("IP address %s has more than one base objects", a)
 	default:
-		baseObj := baseObjs.Results[0]
+		baseObj := baseObjs[0]
 		return &baseObj, nil
 	}
 }
 
-// BaseObjectList represents the shape of data returned by Ralph for the BaseObject
-// endpoint.
-type BaseObjectList struct {
-	Count   int
-	Results []BaseObject
-}
-
 // BaseObject represents an abstract entity used in Ralph as a parent object for
 // physical hosts and therefore - all components associated with them.
 type BaseObject struct {
@@ -72,100 +135,67 @@ func (b *BaseObject) MarshalJSON() ([]byte, error) {
 	return data, nil
 }
 
-// GetEthernets fetches Ethernet objects associated with given BaseObject.
-func (b BaseObject) GetEthernets(c *Client) ([]*Ethernet, error) {
+// getComponents fetches components of a given type (e.g. "Disk", see
+// APIEndpoints) associated with given BaseObject, and unmarshals them into
+// components, which should be a pointer to a slice of pointers (e.g.
+// *[]*Disk).
+func (b BaseObject) getComponents(c *Client, componentType string, components interface{}) error {
 	q := fmt.Sprintf("base_object=%d", b.ID)
-	rawBody, err := c.GetFromRalph(APIEndpoints["Ethernet"], q)
+	rawBody, err := c.GetAllFromRalph(APIEndpoints[componentType], q)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var eths EthernetList
-	if err := json.Unmarshal(rawBody, &eths); err != nil {
-		return nil, fmt.Errorf("error unmarshaling Ethernet: %v", err)
+	if err := json.Unmarshal(rawBody, components); err != nil {
+		return fmt.Errorf("error unmarshaling %s: %v", componentType, err)
 	}
-	ethsPtrs := make([]*Ethernet, eths.Count)
-	for i := 0; i < eths.Count; i++ {
-		ethsPtrs[i] = &eths.Results[i]
+	return nil
+}
+
+// GetEthernets fetches Ethernet objects associated with given BaseObject.
+func (b BaseObject) GetEthernets(c *Client) ([]*Ethernet, error) {
+	var eths []*Ethernet
+	if err := b.getComponents(c, "Ethernet", &eths); err != nil {
+		return nil, err
 	}
-	return ethsPtrs, nil
+	return eths, nil
 }
 
 // GetMemory fetches Memory objects associated with given BaseObject.
 func (b BaseObject) GetMemory(c *Client) ([]*Memory, error) {
-	// Hardcoding such things as the limit below is generally a bad idea, but in
-	// this particular case it won't hurt (the maximum number of results that we
-	// expect from 'memory' endpoint is like 16 or maaaaaybe 32; and BTW, the
-	// default limit in Ralph is just 10).
-	q := fmt.Sprintf("base_object=%d&limit=100", b.ID)
-	rawBody, err := c.GetFromRalph(APIEndpoints["Memory"], q)
-	if err != nil {
+	var mems []*Memory
+	if err := b.getComponents(c, "Memory", &mems); err != nil {
 		return nil, err
 	}
-	var mems MemoryList
-	if err := json.Unmarshal(rawBody, &mems); err != nil {
-		return nil, fmt.Errorf("error unmarshaling Memory: %v", err)
-	}
-	memsPtrs := make([]*Memory, mems.Count)
-	for i := 0; i < mems.Count; i++ {
-		memsPtrs[i] = &mems.Results[i]
-	}
-	return memsPtrs, nil
+	return mems, nil
 }
 
 // GetFibreChannelCards fetches FibreChannelCard objects associated with given
 // BaseObject.
 func (b BaseObject) GetFibreChannelCards(c *Client) ([]*FibreChannelCard, error) {
-	q := fmt.Sprintf("base_object=%d", b.ID)
-	rawBody, err := c.GetFromRalph(APIEndpoints["FibreChannelCard"], q)
-	if err != nil {
+	var cards []*FibreChannelCard
+	if err := b.getComponents(c, "FibreChannelCard", &cards); err != nil {
 		return nil, err
 	}
-	var cards FibreChannelCardList
-	if err := json.Unmarshal(rawBody, &cards); err != nil {
-		return nil, fmt.Errorf("error unmarshaling FibreChannelCard: %v", err)
-	}
-	cardsPtrs := make([]*FibreChannelCard, cards.Count)
-	for i := 0; i < cards.Count; i++ {
-		cardsPtrs[i] = &cards.Results[i]
-	}
-	return cardsPtrs, nil
+	return cards, nil
 }
 
 // GetProcessors fetches Processor objects associated with given
 // BaseObject.
 func (b BaseObject) GetProcessors(c *Client) ([]*Processor, error) {
-	q := fmt.Sprintf("base_object=%d", b.ID)
-	rawBody, err := c.GetFromRalph(APIEndpoints["Processor"], q)
-	if err != nil {
+	var procs []*Processor
+	if err := b.getComponents(c, "Processor", &procs); err != nil {
 		return nil, err
 	}
-	var procs ProcessorList
-	if err := json.Unmarshal(rawBody, &procs); err != nil {
-		return nil, fmt.Errorf("error unmarshaling Processor: %v", err)
-	}
-	procsPtrs := make([]*Processor, procs.Count)
-	for i := 0; i < procs.Count; i++ {
-		procsPtrs[i] = &procs.Results[i]
-	}
-	return procsPtrs, nil
+	return procs, nil
 }
 
 // GetDisks fetches Disk objects associated with given BaseObject.
 func (b BaseObject) GetDisks(c *Client) ([]*Disk, error) {
-	q := fmt.Sprintf("base_object=%d", b.ID)
-	rawBody, err := c.GetFromRalph(APIEndpoints["Disk"], q)
-	if err != nil {
+	var disks []*Disk
+	if err := b.getComponents(c, "Disk", &disks); err != nil {
 		return nil, err
 	}
-	var disks DiskList
-	if err := json.Unmarshal(rawBody, &disks); err != nil {
-		return nil, fmt.Errorf("error unmarshaling Disk: %v", err)
-	}
-	disksPtrs := make([]*Disk, disks.Count)
-	for i := 0; i < disks.Count; i++ {
-		disksPtrs[i] = &disks.Results[i]
-	}
-	return disksPtrs, nil
+	return disks, nil
 }
 
 // GetDataCenterAsset fetches DataCenterAsset object associated with given
@@ -316,6 +346,11 @@ func (d DiskSlotNumber) MarshalJSON() ([]byte, error) {
 	}
 }
 
+// isSet implements optionalField interface.
+func (d DiskSlotNumber) isSet() bool {
+	return d != -1
+}
+
 // UnmarshalJSON deserializes DiskSlotNumber from []byte.
 func (d *DiskSlotNumber) UnmarshalJSON(data []byte) error {
 	switch {
@@ -337,12 +372,6 @@ type Component interface {
 	String() string
 }
 
-// EthernetList represents the shape of data returned by Ralph for Ethernet endpoint.
-type EthernetList struct {
-	Count   int
-	Results []Ethernet
-}
-
 // Ethernet represents a network card on a given host linked to it via
 // BaseObject.
 type Ethernet struct {
@@ -363,115 +392,30 @@ func (e Ethernet) String() string {
 // objects for equality. Please note that Ethernet.ID *is not* taken into
 // account here!
 func (e Ethernet) IsEqualTo(c Component) bool {
-	switch ee := c.(type) {
-	case *Ethernet:
-		switch {
-		case e.BaseObject.ID != ee.BaseObject.ID:
-			return false
-		case e.MACAddress.String() != ee.MACAddress.String():
-			return false
-		case e.ModelName != ee.ModelName:
-			return false
-		case e.Speed != ee.Speed:
-			return false
-		case e.FirmwareVersion != ee.FirmwareVersion:
-			return false
-		default:
-			return true
-		}
-	case Ethernet:
-		// There's some slightly annoying inconsistency in Go - you can access e
-		// fields whenever e is Ethernet or *Ethernet (and that's really
-		// convenient), but when it comes to type assertions, Ethernet and
-		// *Ethernet are different things - and of course, they are, there's no
-		// doubt about that, but...
-		// Anyway, I think it's still better to do some "acrobatics" like this
-		// recursive call below, than make an exact copy of the body of
-		// *Ethernet case...
-		// Or maybe automatic code generation would provide a better solution
-		// here..?
-		// TODO(xor-xor): consider using github.com/clipperhouse/gen for code
-		// generation.
-		return e.IsEqualTo(&ee)
-	default:
-		return false
-	}
+	return ethernetDescriptor.isEqual(e, c)
 }
 
 // CompareEthernets compares two sets of Ethernet objects (old and new) and
-// creates a Diff holding detected changes.
+// creates a Diff holding detected changes, as described by ethernetDescriptor
+// (see compareComponents).
 func CompareEthernets(old, new []*Ethernet) (*Diff, error) {
-	var all []*Ethernet
-	var create, update, delete []*DiffComponent
-	all = append(all, old...)
-	all = append(all, new...)
-
-	for _, eth := range all {
-		switch mac := eth.MACAddress; {
-		case mac.IsIn(new) && !mac.IsIn(old):
-			d, err := NewDiffComponent(eth)
-			if err != nil {
-				return nil, err
-			}
-			create = append(create, d)
-		case mac.IsIn(old) && !mac.IsIn(new):
-			d, err := NewDiffComponent(eth)
-			if err != nil {
-				return nil, err
-			}
-			delete = append(delete, d)
-		default: // is present both in new and old
-			if eth.ID == 0 {
-				// We need to find matching eth in old and assign its ID to the
-				// new one to make an update.
-				var ethOld *Ethernet
-				for _, ethOld = range old {
-					if ethOld.MACAddress.String() == eth.MACAddress.String() {
-						eth.ID = ethOld.ID
-						break
-					}
-				}
-				if eth.ID == 0 {
-					// This really shouldn't happen.
-					return nil, fmt.Errorf("error while preparing Ethernet with MAC %s for update", eth.MACAddress)
-				}
-				// Exclude eth equal to ethOld (no need to make an update in
-				// such case).
-				if !eth.IsEqualTo(ethOld) {
-					d, err := NewDiffComponent(eth)
-					if err != nil {
-						return nil, err
-					}
-					update = append(update, d)
-				}
-			}
-		}
-	}
-	return &Diff{
-		Create: create,
-		Delete: delete,
-		Update: update,
-	}, nil
+	return compareComponents(ethernetDescriptor, toComponents(old), toComponents(new))
 }
 
-// MemoryList represents the shape of data returned by Ralph for Memory endpoint.
-type MemoryList struct {
-	Count   int
-	Results []Memory
-}
-
-// Memory represents RAM installed on a given host.
+// Memory represents RAM installed on a given host. Slot is the locator of a
+// given DIMM as reported by BMC (e.g. "DIMM.Socket.A1"), which is optional.
 type Memory struct {
 	ID         int        `json:"id"`
 	BaseObject BaseObject `json:"base_object"`
 	ModelName  string     `json:"model_name"`
 	Size       int        `json:"size"`
 	Speed      int        `json:"speed"`
+	Slot       string     `json:"slot"`
 }
 
 func (m Memory) String() string {
-	return fmt.Sprintf("Memory{id: %d, base_object_id: %d, model_name: %s, size: %d, speed: %d}",
-		m.ID, m.BaseObject.ID, m.ModelName, m.Size, m.Speed)
+	return fmt.Sprintf("Memory{id: %d, base_object_id: %d, model_name: %s, size: %d, speed: %d, slot: %s}",
+		m.ID, m.BaseObject.ID, m.ModelName, m.Size, m.Speed, m.Slot)
 }
 
 // MarshalJSON serializes Memory into []byte.
@@ -482,6 +426,7 @@ func (m Memory) MarshalJSON() ([]byte, error) {
 		"model_name":  m.ModelName,
 		"size":        m.Size,
 		"speed":       m.Speed,
+		"slot":        m.Slot,
 	})
 	if err != nil {
 		return []byte{}, fmt.Errorf("error marshaling Memory: %v", err)
@@ -493,130 +438,14 @@ func (m Memory) MarshalJSON() ([]byte, error) {
 // objects for equality. Please note that Memory.ID *is not* taken into account
 // here!
 func (m Memory) IsEqualTo(c Component) bool {
-	switch mm := c.(type) {
-	case *Memory:
-		switch {
-		case m.BaseObject.ID != mm.BaseObject.ID:
-			return false
-		case m.ModelName != mm.ModelName:
-			return false
-		case m.Size != mm.Size:
-			return false
-		case m.Speed != mm.Speed:
-			return false
-		default:
-			return true
-		}
-	case Memory:
-		return m.IsEqualTo(&mm)
-	default:
-		return false
-	}
+	return memoryDescriptor.isEqual(m, c)
 }
 
 // CompareMemory compares two sets of Memory objects (old and new) and creates a
-// Diff holding detected changes.
+// Diff holding detected changes, as described by memoryDescriptor (see
+// compareComponents).
 func CompareMemory(old, new []*Memory) (*Diff, error) {
-	// Since Memory instances are not unique (i.e., w/o considering Memory.ID),
-	// we won't be using Diff.Update here.
-	var create, delete []*DiffComponent
-
-	// Keys for these maps are constructed from all Memory field values *except* ID.
-	counterOld := make(map[string]int)
-	counterNew := make(map[string]int)
-	oldAsMap := make(map[string][]*Memory)
-	newAsMap := make(map[string][]*Memory)
-
-	// Populate oldAsMap/newAsMap.
-	for _, m := range old {
-		k := fmt.Sprintf("%d__%s__%d__%d",
-			m.BaseObject.ID, strings.Replace(m.ModelName, " ", "_", -1), m.Size, m.Speed)
-		counterOld[k]++
-		oldAsMap[k] = append(oldAsMap[k], m)
-	}
-	for _, m := range new {
-		k := fmt.Sprintf("%d__%s__%d__%d",
-			m.BaseObject.ID, strings.Replace(m.ModelName, " ", "_", -1), m.Size, m.Speed)
-		counterNew[k]++
-		newAsMap[k] = append(newAsMap[k], m)
-	}
-
-	if len(new) == 0 {
-		for _, m := range old {
-			d, err := NewDiffComponent(m)
-			if err != nil {
-				return nil, err
-			}
-			delete = append(delete, d)
-		}
-		return &Diff{
-			Create: []*DiffComponent{},
-			Delete: delete,
-			Update: []*DiffComponent{},
-		}, nil
-	}
-
-	// Find the differences between counterNew and counterOld and populate
-	// Diff.Create and Diff.Delete lists.
-	for k, v := range counterNew {
-		switch {
-		case v == counterOld[k]:
-			continue
-		case v > counterOld[k]:
-			// Create (v - counterOld[k]) instances of k.
-			for i := 0; i < v-counterOld[k]; i++ {
-				d, err := NewDiffComponent(newAsMap[k][0])
-				if err != nil {
-					return nil, err
-				}
-				create = append(create, d)
-			}
-		case v < counterOld[k]:
-			// Delete (counterOld[k] - v ) instances of k.
-			for i := 0; i < counterOld[k]-v; i++ {
-				d, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, d)
-			}
-		}
-	}
-
-	// We also need to make sure that keys which are only in counterOld will be
-	// deleted.
-	for k, v := range counterOld {
-		presentInNew := false
-		for kk := range counterNew {
-			if k == kk {
-				presentInNew = true
-				break
-			}
-		}
-		if !presentInNew {
-			// Delete v instances of k.
-			for i := 0; i < v; i++ {
-				d, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, d)
-			}
-		}
-	}
-
-	return &Diff{
-		Create: create,
-		Delete: delete,
-		Update: []*DiffComponent{},
-	}, nil
-}
-
-// FibreChannelCardList represents the shape of data returned by Ralph for
-// FibreChannelCard endpoint.
-type FibreChannelCardList struct {
-	Count   int
-	Results []FibreChannelCard
+	return compareComponents(memoryDescriptor, toComponents(old), toComponents(new))
 }
 
 // FibreChannelCard represents a single fibre channel card/controller on a given
@@ -638,278 +467,45 @@ func (f FibreChannelCard) String() string {
 // IsEqualTo implements Component interface. This method compares two
 // FibreChannelCard objects for equality. Their IDs are not taken into account.
 func (f FibreChannelCard) IsEqualTo(c Component) bool {
-	switch ff := c.(type) {
-	case *FibreChannelCard:
-		switch {
-		case f.BaseObject.ID != ff.BaseObject.ID:
-			return false
-		case f.ModelName != ff.ModelName:
-			return false
-		case f.Speed != ff.Speed:
-			return false
-		case f.WWN != ff.WWN:
-			return false
-		case f.FirmwareVersion != ff.FirmwareVersion:
-			return false
-		default:
-			return true
-		}
-	case FibreChannelCard:
-		return f.IsEqualTo(&ff)
-	default:
-		return false
-	}
+	return fibreChannelCardDescriptor.isEqual(f, c)
 }
 
 // CompareFibreChannelCards compares two sets of FibreChannelCard objects (old
-// and new) and creates a Diff holding detected changes.
+// and new) and creates a Diff holding detected changes, as described by
+// fibreChannelCardDescriptor (see compareComponents).
 func CompareFibreChannelCards(old, new []*FibreChannelCard) (*Diff, error) {
-	var create, delete []*DiffComponent
-
-	// At first, it may seem that FibreChannelCards can be compared as unique
-	// objects, thanks to WWN, but unfortunately, this field will be empty quite
-	// often, so we have to compare them in the same way as with Memory.
-
-	// Keys for these maps are constructed from all FibreChannelCard field
-	// values *except* ID.
-	counterOld := make(map[string]int)
-	counterNew := make(map[string]int)
-	oldAsMap := make(map[string][]*FibreChannelCard)
-	newAsMap := make(map[string][]*FibreChannelCard)
-
-	// Populate oldAsMap/newAsMap.
-	for _, fc := range old {
-		k := fmt.Sprintf("%d__%s__%s__%s__%s",
-			fc.BaseObject.ID, strings.Replace(fc.ModelName, " ", "_", -1), fc.Speed, fc.WWN, fc.FirmwareVersion)
-		counterOld[k]++
-		oldAsMap[k] = append(oldAsMap[k], fc)
-	}
-	for _, fc := range new {
-		k := fmt.Sprintf("%d__%s__%s__%s__%s",
-			fc.BaseObject.ID, strings.Replace(fc.ModelName, " ", "_", -1), fc.Speed, fc.WWN, fc.FirmwareVersion)
-		counterNew[k]++
-		newAsMap[k] = append(newAsMap[k], fc)
-	}
-
-	if len(new) == 0 {
-		for _, m := range old {
-			d, err := NewDiffComponent(m)
-			if err != nil {
-				return nil, err
-			}
-			delete = append(delete, d)
-		}
-		return &Diff{
-			Create: []*DiffComponent{},
-			Delete: delete,
-			Update: []*DiffComponent{},
-		}, nil
-	}
-
-	// Find the differences between counterNew and counterOld and populate
-	// Diff.Create and Diff.Delete lists.
-	for k, v := range counterNew {
-		switch {
-		case v == counterOld[k]:
-			continue
-		case v > counterOld[k]:
-			// Create (v - counterOld[k]) instances of k.
-			for i := 0; i < v-counterOld[k]; i++ {
-				d, err := NewDiffComponent(newAsMap[k][0])
-				if err != nil {
-					return nil, err
-				}
-				create = append(create, d)
-			}
-		case v < counterOld[k]:
-			// Delete (counterOld[k] - v ) instances of k.
-			for i := 0; i < counterOld[k]-v; i++ {
-				d, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, d)
-			}
-		}
-	}
-
-	// We also need to make sure that keys which are only in counterOld will be
-	// deleted.
-	for k, v := range counterOld {
-		presentInNew := false
-		for kk := range counterNew {
-			if k == kk {
-				presentInNew = true
-				break
-			}
-		}
-		if !presentInNew {
-			// Delete v instances of k.
-			for i := 0; i < v; i++ {
-				d, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, d)
-			}
-		}
-	}
-
-	return &Diff{
-		Create: create,
-		Delete: delete,
-		Update: []*DiffComponent{},
-	}, nil
+	return compareComponents(fibreChannelCardDescriptor, toComponents(old), toComponents(new))
 }
 
-// ProcessorList represents the shape of data returned by Ralph for Processor
-// endpoint.
-type ProcessorList struct {
-	Count   int
-	Results []Processor
-}
-
-// Processor represents a single processor on a given host.
+// Processor represents a single processor on a given host. Socket is the
+// socket it's installed in, as reported by BMC (e.g. "CPU.Socket.1"), which is
+// optional.
 type Processor struct {
 	ID         int        `json:"id"`
 	BaseObject BaseObject `json:"base_object"`
 	ModelName  string     `json:"model_name"`
 	Speed      int        `json:"speed"`
 	Cores      int        `json:"cores"`
+	Socket     string     `json:"socket"`
 }
 
 func (p Processor) String() string {
-	return fmt.Sprintf("Processor{id: %d, base_object_id: %d, model_name: %s, speed: %d, cores: %d}",
-		p.ID, p.BaseObject.ID, p.ModelName, p.Speed, p.Cores)
+	return fmt.Sprintf("Processor{id: %d, base_object_id: %d, model_name: %s, speed: %d, cores: %d, socket: %s}",
+		p.ID, p.BaseObject.ID, p.ModelName, p.Speed, p.Cores, p.Socket)
 }
 
 // IsEqualTo implements Component interface. This method compares two Processor
 // objects for equality. Please note that Processor.ID *is not* taken into
 // account here!
 func (p Processor) IsEqualTo(c Component) bool {
-	switch pp := c.(type) {
-	case *Processor:
-		switch {
-		case p.BaseObject.ID != pp.BaseObject.ID:
-			return false
-		case p.ModelName != pp.ModelName:
-			return false
-		case p.Speed != pp.Speed:
-			return false
-		case p.Cores != pp.Cores:
-			return false
-		default:
-			return true
-		}
-	case Processor:
-		return p.IsEqualTo(&pp)
-	default:
-		return false
-	}
+	return processorDescriptor.isEqual(p, c)
 }
 
 // CompareProcessors compares two sets of Processor objects (old and new) and
-// creates a Diff holding detected changes.
+// creates a Diff holding detected changes, as described by processorDescriptor
+// (see compareComponents).
 func CompareProcessors(old, new []*Processor) (*Diff, error) {
-	// Since Processor instances are not unique (i.e., w/o considering Processor.ID),
-	// we won't be using Diff.Update here.
-	var create, delete []*DiffComponent
-
-	// Keys for these maps are constructed from all Processor field values *except* ID.
-	counterOld := make(map[string]int)
-	counterNew := make(map[string]int)
-	oldAsMap := make(map[string][]*Processor)
-	newAsMap := make(map[string][]*Processor)
-
-	// Populate oldAsMap/newAsMap.
-	for _, p := range old {
-		k := fmt.Sprintf("%d__%s__%d__%d",
-			p.BaseObject.ID, strings.Replace(p.ModelName, " ", "_", -1), p.Speed, p.Cores)
-		counterOld[k]++
-		oldAsMap[k] = append(oldAsMap[k], p)
-	}
-	for _, p := range new {
-		k := fmt.Sprintf("%d__%s__%d__%d",
-			p.BaseObject.ID, strings.Replace(p.ModelName, " ", "_", -1), p.Speed, p.Cores)
-		counterNew[k]++
-		newAsMap[k] = append(newAsMap[k], p)
-	}
-
-	if len(new) == 0 {
-		for _, p := range old {
-			d, err := NewDiffComponent(p)
-			if err != nil {
-				return nil, err
-			}
-			delete = append(delete, d)
-		}
-		return &Diff{
-			Create: []*DiffComponent{},
-			Delete: delete,
-			Update: []*DiffComponent{},
-		}, nil
-	}
-
-	// Find the differences between counterNew and counterOld and populate
-	// Diff.Create and Diff.Delete lists.
-	for k, v := range counterNew {
-		switch {
-		case v == counterOld[k]:
-			continue
-		case v > counterOld[k]:
-			// Create (v - counterOld[k]) instances of k.
-			for i := 0; i < v-counterOld[k]; i++ {
-				d, err := NewDiffComponent(newAsMap[k][0])
-				if err != nil {
-					return nil, err
-				}
-				create = append(create, d)
-			}
-		case v < counterOld[k]:
-			// Delete (counterOld[k] - v ) instances of k.
-			for i := 0; i < counterOld[k]-v; i++ {
-				d, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, d)
-			}
-		}
-	}
-
-	// We also need to make sure that keys which are only in counterOld will be
-	// deleted.
-	for k, v := range counterOld {
-		presentInNew := false
-		for kk := range counterNew {
-			if k == kk {
-				presentInNew = true
-				break
-			}
-		}
-		if !presentInNew {
-			// Delete v instances of k.
-			for i := 0; i < v; i++ {
-				d, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, d)
-			}
-		}
-	}
-
-	return &Diff{
-		Create: create,
-		Delete: delete,
-		Update: []*DiffComponent{},
-	}, nil
-}
-
-// DiskList represents the shape of data returned by Ralph for Disk endpoint.
-type DiskList struct {
-	Count   int
-	Results []Disk
+	return compareComponents(processorDescriptor, toComponents(old), toComponents(new))
 }
 
 // Disk represents a single disk drive (be it HDD or SSD) on a given host.
@@ -936,127 +532,129 @@ func (d Disk) String() string {
 // objects for equality. Please note that Disk.ID *is not* taken into account
 // here!
 func (d Disk) IsEqualTo(c Component) bool {
-	switch dd := c.(type) {
-	case *Disk:
-		switch {
-		case d.BaseObject.ID != dd.BaseObject.ID:
-			return false
-		case d.ModelName != dd.ModelName:
-			return false
-		case d.Size != dd.Size:
-			return false
-		case d.SerialNumber != dd.SerialNumber:
-			return false
-		case d.Slot != dd.Slot:
-			return false
-		case d.FirmwareVersion != dd.FirmwareVersion:
-			return false
-		default:
-			return true
-		}
-	case Disk:
-		return d.IsEqualTo(&dd)
-	default:
-		return false
-	}
+	return diskDescriptor.isEqual(d, c)
 }
 
 // CompareDisks compares two sets of Disk objects (old and new) and creates a
-// Diff holding detected changes.
+// Diff holding detected changes, as described by diskDescriptor (see
+// compareComponents).
 func CompareDisks(old, new []*Disk) (*Diff, error) {
-	// Since Disk instances are not unique (i.e., w/o considering Disk.ID),
-	// we won't be using Diff.Update here.
-	var create, delete []*DiffComponent
-
-	// Keys for these maps are constructed from all Disk field values *except* ID.
-	counterOld := make(map[string]int)
-	counterNew := make(map[string]int)
-	oldAsMap := make(map[string][]*Disk)
-	newAsMap := make(map[string][]*Disk)
-
-	// Populate oldAsMap/newAsMap.
-	for _, d := range old {
-		k := fmt.Sprintf("%d__%s__%d__%s__%d__%s",
-			d.BaseObject.ID, strings.Replace(d.ModelName, " ", "_", -1), d.Size, d.SerialNumber, d.Slot, d.FirmwareVersion)
-		counterOld[k]++
-		oldAsMap[k] = append(oldAsMap[k], d)
-	}
-	for _, d := range new {
-		k := fmt.Sprintf("%d__%s__%d__%s__%d__%s",
-			d.BaseObject.ID, strings.Replace(d.ModelName, " ", "_", -1), d.Size, d.SerialNumber, d.Slot, d.FirmwareVersion)
-		counterNew[k]++
-		newAsMap[k] = append(newAsMap[k], d)
-	}
+	return compareComponents(diskDescriptor, toComponents(old), toComponents(new))
+}
 
-	if len(new) == 0 {
-		for _, d := range old {
-			dc, err := NewDiffComponent(d)
-			if err != nil {
-				return nil, err
-			}
-			delete = append(delete, dc)
-		}
-		return &Diff{
-			Create: []*DiffComponent{},
-			Delete: delete,
-			Update: []*DiffComponent{},
-		}, nil
-	}
+// PowerSupply represents a single power supply unit (PSU) on a given host.
+type PowerSupply struct {
+	ID              int        `json:"id"`
+	BaseObject      BaseObject `json:"base_object"`
+	ModelName       string     `json:"model_name"`
+	Wattage         int        `json:"wattage"`
+	SerialNumber    string     `json:"serial_number"`
+	FirmwareVersion string     `json:"firmware_version"`
+}
 
-	// Find the differences between counterNew and counterOld and populate
-	// Diff.Create and Diff.Delete lists.
-	for k, v := range counterNew {
-		switch {
-		case v == counterOld[k]:
-			continue
-		case v > counterOld[k]:
-			// Create (v - counterOld[k]) instances of k.
-			for i := 0; i < v-counterOld[k]; i++ {
-				dc, err := NewDiffComponent(newAsMap[k][0])
-				if err != nil {
-					return nil, err
-				}
-				create = append(create, dc)
-			}
-		case v < counterOld[k]:
-			// Delete (counterOld[k] - v ) instances of k.
-			for i := 0; i < counterOld[k]-v; i++ {
-				dc, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, dc)
-			}
-		}
-	}
+func (p PowerSupply) String() string {
+	return fmt.Sprintf("PowerSupply{id: %d, base_object_id: %d, model_name: %s, wattage: %d, serial_number: %s, firmware_version: %s}",
+		p.ID, p.BaseObject.ID, p.ModelName, p.Wattage, p.SerialNumber, p.FirmwareVersion)
+}
 
-	// We also need to make sure that keys which are only in counterOld will be
-	// deleted.
-	for k, v := range counterOld {
-		presentInNew := false
-		for kk := range counterNew {
-			if k == kk {
-				presentInNew = true
-				break
-			}
-		}
-		if !presentInNew {
-			// Delete v instances of k.
-			for i := 0; i < v; i++ {
-				dc, err := NewDiffComponent(oldAsMap[k][i])
-				if err != nil {
-					return nil, err
-				}
-				delete = append(delete, dc)
-			}
-		}
-	}
+// IsEqualTo implements Component interface. This method compares two
+// PowerSupply objects for equality. Their IDs are not taken into account.
+func (p PowerSupply) IsEqualTo(c Component) bool {
+	return powerSupplyDescriptor.isEqual(p, c)
+}
+
+// ComparePowerSupplies compares two sets of PowerSupply objects (old and new)
+// and creates a Diff holding detected changes, as described by
+// powerSupplyDescriptor (see compareComponents).
+func ComparePowerSupplies(old, new []*PowerSupply) (*Diff, error) {
+	return compareComponents(powerSupplyDescriptor, toComponents(old), toComponents(new))
+}
+
+// StorageController represents a single storage (e.g. RAID) controller on a
+// given host.
+type StorageController struct {
+	ID              int        `json:"id"`
+	BaseObject      BaseObject `json:"base_object"`
+	ModelName       string     `json:"model_name"`
+	FirmwareVersion string     `json:"firmware_version"`
+	CacheSize       int        `json:"cache_size"`
+}
+
+func (s StorageController) String() string {
+	return fmt.Sprintf("StorageController{id: %d, base_object_id: %d, model_name: %s, firmware_version: %s, cache_size: %d}",
+		s.ID, s.BaseObject.ID, s.ModelName, s.FirmwareVersion, s.CacheSize)
+}
+
+// IsEqualTo implements Component interface. This method compares two
+// StorageController objects for equality. Their IDs are not taken into
+// account.
+func (s StorageController) IsEqualTo(c Component) bool {
+	return storageControllerDescriptor.isEqual(s, c)
+}
+
+// CompareStorageControllers compares two sets of StorageController objects (old
+// and new) and creates a Diff holding detected changes, as described by
+// storageControllerDescriptor (see compareComponents).
+func CompareStorageControllers(old, new []*StorageController) (*Diff, error) {
+	return compareComponents(storageControllerDescriptor, toComponents(old), toComponents(new))
+}
 
-	return &Diff{
-		Create: create,
-		Delete: delete,
-		Update: []*DiffComponent{},
-	}, nil
+// GPU represents a single graphics card (or other GPU-based accelerator) on a
+// given host.
+type GPU struct {
+	ID              int        `json:"id"`
+	BaseObject      BaseObject `json:"base_object"`
+	ModelName       string     `json:"model_name"`
+	SerialNumber    string     `json:"serial_number"`
+	FirmwareVersion string     `json:"firmware_version"`
+}
+
+func (g GPU) String() string {
+	return fmt.Sprintf("GPU{id: %d, base_object_id: %d, model_name: %s, serial_number: %s, firmware_version: %s}",
+		g.ID, g.BaseObject.ID, g.ModelName, g.SerialNumber, g.FirmwareVersion)
+}
+
+// IsEqualTo implements Component interface. This method compares two GPU
+// objects for equality. Their IDs are not taken into account.
+func (g GPU) IsEqualTo(c Component) bool {
+	return gpuDescriptor.isEqual(g, c)
+}
+
+// CompareGPUs compares two sets of GPU objects (old and new) and creates a Diff
+// holding detected changes, as described by gpuDescriptor (see
+// compareComponents).
+func CompareGPUs(old, new []*GPU) (*Diff, error) {
+	return compareComponents(gpuDescriptor, toComponents(old), toComponents(new))
+}
+
+// NVMeDrive represents a single NVMe drive on a given host. Such drives are
+// not reported as Disks, since they are attached via PCIe instead of a storage
+// controller.
+type NVMeDrive struct {
+	ID              int        `json:"id"`
+	BaseObject      BaseObject `json:"base_object"`
+	ModelName       string     `json:"model_name"`
+	Size            int        `json:"size"`
+	SerialNumber    string     `json:"serial_number"`
+	FirmwareVersion string     `json:"firmware_version"`
+}
+
+func (n NVMeDrive) String() string {
+	return fmt.Sprintf("NVMeDrive{id: %d, base_object_id: %d, model_name: %s, size: %d, serial_number: %s, firmware_version: %s}",
+		n.ID, n.BaseObject.ID, n.ModelName, n.Size, n.SerialNumber, n.FirmwareVersion)
+}
+
+// IsEqualTo implements Component interface. This method compares two NVMeDrive
+// objects for equality. Their IDs are not taken into account.
+func (n NVMeDrive) IsEqualTo(c Component) bool {
+	return nvmeDriveDescriptor.isEqual(n, c)
+}
+
+// CompareNVMeDrives compares two sets of NVMeDrive objects (old and new) and
+// creates a Diff holding detected changes, as described by nvmeDriveDescriptor
+// (see compareComponents).
+func CompareNVMeDrives(old, new []*NVMeDrive) (*Diff, error) {
+	return compareComponents(nvmeDriveDescriptor, toComponents(old), toComponents(new))
 }
 
 // DataCenterAsset is meant only for updating firmware_version and bios_version
@@ -1097,6 +695,29 @@ func (a DataCenterAsset) String() string {
 	return fmt.Sprintf("DataCenterAsset{%s}", strings.TrimSuffix(str, ", "))
 }
 
+// Copy returns a deep copy of DataCenterAsset (i.e. with its fields pointing
+// to copies of the original values, not to the same ones).
+func (a DataCenterAsset) Copy() *DataCenterAsset {
+	var copyString = func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		ss := *s
+		return &ss
+	}
+	var c = DataCenterAsset{
+		FirmwareVersion: copyString(a.FirmwareVersion),
+		BIOSVersion:     copyString(a.BIOSVersion),
+		Remarks:         copyString(a.Remarks),
+		SerialNumber:    copyString(a.SerialNumber),
+	}
+	if a.ID != nil {
+		id := *a.ID
+		c.ID = &id
+	}
+	return &c
+}
+
 // IsEqualTo implements Component interface. This method compares two
 // DataCenterAsset objects for equality. Please note that DataCenterAsset.ID *is
 // not* taken into account here, and that this method's body slightly differs
@@ -1151,7 +772,7 @@ type IPAddress struct {
 	Ethernet     *Ethernet
 }
 
-// IPAddressList represents the shape of data returned by Ralph for IPAddress endpoint.
+// IPAddressList holds IP addresses fetched from Ralph (see getIPAddresses).
 type IPAddressList struct {
 	Count   int
 	Results []IPAddress
@@ -1160,12 +781,13 @@ type IPAddressList struct {
 // getIPAddress is a helper function for querying "ipaddresses" endpoint.
 func getIPAddresses(query string, c *Client) (*IPAddressList, error) {
 	var addrs IPAddressList
-	rawBody, err := c.GetFromRalph(APIEndpoints["IPAddress"], query)
+	rawBody, err := c.GetAllFromRalph(APIEndpoints["IPAddress"], query)
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(rawBody, &addrs); err != nil {
+	if err := json.Unmarshal(rawBody, &addrs.Results); err != nil {
 		return nil, fmt.Errorf("error unmarshaling IPAddress: %v", err)
 	}
+	addrs.Count = len(addrs.Results)
 	return &addrs, nil
 }
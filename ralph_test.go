@@ -33,6 +33,92 @@ func TestNewAddr(t *testing.T) {
 	}
 }
 
+func TestNewAddrs(t *testing.T) {
+	var cases = map[string]struct {
+		input  string
+		errMsg string
+		want   []Addr
+	}{
+		"#0 Single IP address": {
+			"10.20.30.40",
+			"",
+			[]Addr{"10.20.30.40"},
+		},
+		"#1 Network and broadcast addresses should be skipped": {
+			"10.20.30.40/30",
+			"",
+			[]Addr{"10.20.30.41", "10.20.30.42"},
+		},
+		"#2 /31 network": {
+			"10.20.30.40/31",
+			"",
+			[]Addr{"10.20.30.40", "10.20.30.41"},
+		},
+		"#3 /32 network": {
+			"10.20.30.40/32",
+			"",
+			[]Addr{"10.20.30.40"},
+		},
+		"#4 Crossing octet boundary": {
+			"10.20.30.255/23",
+			"",
+			nil, // checked only for length below
+		},
+		"#5 Network too large": {
+			"10.0.0.0/8",
+			"too large",
+			nil,
+		},
+		"#6 Invalid CIDR": {
+			"10.20.30.40/33",
+			"invalid CIDR address",
+			nil,
+		},
+	}
+	for tn, tc := range cases {
+		got, err := NewAddrs(tc.input)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		case tc.want == nil:
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if len(got) != 510 || got[0] != "10.20.30.1" || got[509] != "10.20.31.254" {
+				t.Errorf("%s\n got: %d addrs (%s...%s)\nwant: 510 addrs (10.20.30.1...10.20.31.254)",
+					tn, len(got), got[0], got[len(got)-1])
+			}
+		default:
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestReadHostsFile(t *testing.T) {
+	input := `# rack A
+10.20.30.40
+
+  10.20.31.0/24
+# rack B
+some.host.local
+`
+	want := []string{"10.20.30.40", "10.20.31.0/24", "some.host.local"}
+	got, err := ReadHostsFile(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !TestEqStr(got, want) {
+		t.Errorf("\n got: %v\nwant: %v", got, want)
+	}
+}
+
 func TestEthernetIsEqualTo(t *testing.T) {
 	var cases = map[string]struct {
 		eth  *Ethernet
@@ -101,42 +187,42 @@ func TestMemoryIsEqualTo(t *testing.T) {
 		want bool
 	}{
 		"#0 All equal": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			true,
 		},
 		"#1 All different": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			&Memory{2, BaseObject{2}, "DIMM", 4096, 1333},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			&Memory{2, BaseObject{2}, "DIMM", 4096, 1333, ""},
 			false,
 		},
 		"#2 Different BaseObject.ID": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			&Memory{1, BaseObject{2}, "Samsung DDR3 DIMM", 16384, 1600},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			&Memory{1, BaseObject{2}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			false,
 		},
 		"#3 Different ModelName": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			&Memory{1, BaseObject{1}, "DIMM", 16384, 1600},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			&Memory{1, BaseObject{1}, "DIMM", 16384, 1600, ""},
 			false,
 		},
 		"#4 Different Size": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 4096, 1600},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 4096, 1600, ""},
 			false,
 		},
 		"#5 Different Speed": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1333},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1333, ""},
 			false,
 		},
 		"#6 Component given as object, not pointer": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-			Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			true,
 		},
 		"#7 Component other than Memory given": {
-			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+			&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			FakeComponent{},
 			false,
 		},
@@ -216,42 +302,42 @@ func TestProcessorIsEqualTo(t *testing.T) {
 		want bool
 	}{
 		"#0 All equal": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			true,
 		},
 		"#1 All different": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			&Processor{2, BaseObject{2}, "Generic Processor", 2400, 4},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			&Processor{2, BaseObject{2}, "Generic Processor", 2400, 4, ""},
 			false,
 		},
 		"#2 Different BaseObject.ID": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			&Processor{1, BaseObject{2}, "Intel(R) Xeon(R)", 2600, 8},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			&Processor{1, BaseObject{2}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			false,
 		},
 		"#3 Different ModelName": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			&Processor{1, BaseObject{1}, "Generic Processor", 2600, 8},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			&Processor{1, BaseObject{1}, "Generic Processor", 2600, 8, ""},
 			false,
 		},
 		"#4 Different Speed": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2400, 8},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2400, 8, ""},
 			false,
 		},
 		"#5 Different Cores": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 4},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 4, ""},
 			false,
 		},
 		"#6 Component given as object, not pointer": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-			Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			true,
 		},
 		"#7 Component other than Processor given": {
-			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+			&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			FakeComponent{},
 			false,
 		},
@@ -606,6 +692,14 @@ func TestCompareEthernets(t *testing.T) {
 							Speed:           "10 Gbps",
 							FirmwareVersion: "2.2.2",
 						},
+						Old: &Ethernet{
+							ID:              1,
+							BaseObject:      BaseObject{1},
+							MACAddress:      macs["a1:b2:c3:d4:e5:f6"],
+							ModelName:       "",
+							Speed:           "1 Gbps",
+							FirmwareVersion: "1.1.1",
+						},
 					},
 				},
 				Delete: []*DiffComponent{},
@@ -673,15 +767,15 @@ func TestCompareMemory(t *testing.T) {
 		"#1 Create": {
 			memOld: []*Memory{},
 			memNew: []*Memory{
-				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{
 					&DiffComponent{
 						ID:        0,
 						Name:      "Memory",
-						Data:      []byte(`{"base_object":1,"id":0,"model_name":"Samsung DDR3 DIMM","size":16384,"speed":1600}`),
-						Component: &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+						Data:      []byte(`{"base_object":1,"id":0,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
+						Component: &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 					},
 				},
 				Update: []*DiffComponent{},
@@ -690,11 +784,11 @@ func TestCompareMemory(t *testing.T) {
 		},
 		"#2 Delete (old > new && new > 0)": {
 			memOld: []*Memory{
-				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
-				&Memory{2, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+				&Memory{2, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			memNew: []*Memory{
-				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{},
@@ -703,15 +797,15 @@ func TestCompareMemory(t *testing.T) {
 					&DiffComponent{
 						ID:        1,
 						Name:      "Memory",
-						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"speed":1600}`),
-						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
+						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 					},
 				},
 			},
 		},
 		"#3 Delete (old > new && new == 0)": {
 			memOld: []*Memory{
-				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			memNew: []*Memory{},
 			want: &Diff{
@@ -721,30 +815,30 @@ func TestCompareMemory(t *testing.T) {
 					&DiffComponent{
 						ID:        1,
 						Name:      "Memory",
-						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"speed":1600}`),
-						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
+						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 					},
 				},
 			},
 		},
 		// Note that we test "Delete and Create" scenario instead of "Update" -
-		// in case of Memory, the latter doesn't make sense because Memory
-		// instances are not unique (in contrast to e.g. Ethernet, whose
-		// instances can be distinguished by their MACAddresses).
+		// in case of Memory without slot, the latter doesn't make sense because
+		// such Memory instances are not unique (in contrast to e.g. Ethernet,
+		// whose instances can be distinguished by their MACAddresses).
 		"#4 Update by \"Delete and Create\"": {
 			memOld: []*Memory{
-				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			memNew: []*Memory{
-				&Memory{0, BaseObject{1}, "DIMM", 4096, 1333},
+				&Memory{0, BaseObject{1}, "DIMM", 4096, 1333, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{
 					&DiffComponent{
 						ID:        0,
 						Name:      "Memory",
-						Data:      []byte(`{"base_object":1,"id":0,"model_name":"DIMM","size":4096,"speed":1333}`),
-						Component: &Memory{0, BaseObject{1}, "DIMM", 4096, 1333},
+						Data:      []byte(`{"base_object":1,"id":0,"model_name":"DIMM","size":4096,"slot":"","speed":1333}`),
+						Component: &Memory{0, BaseObject{1}, "DIMM", 4096, 1333, ""},
 					},
 				},
 				Update: []*DiffComponent{},
@@ -752,18 +846,18 @@ func TestCompareMemory(t *testing.T) {
 					&DiffComponent{
 						ID:        1,
 						Name:      "Memory",
-						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"speed":1600}`),
-						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"","speed":1600}`),
+						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 					},
 				},
 			},
 		},
 		"#5 Don't do anything (both new and old Memory is the same)": {
 			memOld: []*Memory{
-				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			memNew: []*Memory{
-				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600},
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{},
@@ -771,6 +865,71 @@ func TestCompareMemory(t *testing.T) {
 				Delete: []*DiffComponent{},
 			},
 		},
+		"#6 Update (matched by slot)": {
+			memOld: []*Memory{
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A1"},
+				&Memory{2, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A2"},
+			},
+			memNew: []*Memory{
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A2"},
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 32768, 1600, "DIMM.Socket.A1"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "Memory",
+						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":32768,"slot":"DIMM.Socket.A1","speed":1600}`),
+						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 32768, 1600, "DIMM.Socket.A1"},
+						Old:       &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A1"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#7 Don't do anything (slot missing in Ralph)": {
+			memOld: []*Memory{
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+				&Memory{2, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, ""},
+			},
+			memNew: []*Memory{
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A1"},
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A2"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#8 Update by \"Delete and Create\" (different slots)": {
+			memOld: []*Memory{
+				&Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A1"},
+			},
+			memNew: []*Memory{
+				&Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A2"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{
+					&DiffComponent{
+						ID:        0,
+						Name:      "Memory",
+						Data:      []byte(`{"base_object":1,"id":0,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"DIMM.Socket.A2","speed":1600}`),
+						Component: &Memory{0, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A2"},
+					},
+				},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "Memory",
+						Data:      []byte(`{"base_object":1,"id":1,"model_name":"Samsung DDR3 DIMM","size":16384,"slot":"DIMM.Socket.A1","speed":1600}`),
+						Component: &Memory{1, BaseObject{1}, "Samsung DDR3 DIMM", 16384, 1600, "DIMM.Socket.A1"},
+					},
+				},
+			},
+		},
 	}
 	for tn, tc := range cases {
 		got, err := CompareMemory(tc.memOld, tc.memNew)
@@ -863,9 +1022,9 @@ func TestCompareFibreChannelCards(t *testing.T) {
 			},
 		},
 		// Note that we test "Delete and Create" scenario instead of "Update" -
-		// in case of FibreChannelCard, the latter doesn't make sense because
-		// FibreChannelCard instances are not guaranteed to be unique (and
-		// that's because WWN field is not required).
+		// in case of FibreChannelCard without WWN, the latter doesn't make
+		// sense because such FibreChannelCard instances are not unique (WWN
+		// field is not required).
 		"#4 Update by \"Delete and Create\"": {
 			fccOld: []*FibreChannelCard{
 				&FibreChannelCard{1, BaseObject{1}, "Generic FC Card", "4 Gbit", "", "1.1.1"},
@@ -906,6 +1065,27 @@ func TestCompareFibreChannelCards(t *testing.T) {
 				Delete: []*DiffComponent{},
 			},
 		},
+		"#6 Update (matched by WWN)": {
+			fccOld: []*FibreChannelCard{
+				&FibreChannelCard{1, BaseObject{1}, "Generic FC Card", "4 Gbit", "aabbccddeeff0011", "1.1.1"},
+			},
+			fccNew: []*FibreChannelCard{
+				&FibreChannelCard{0, BaseObject{1}, "Generic FC Card", "4 Gbit", "aabbccddeeff0011", "2.2.2"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "FibreChannelCard",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Generic FC Card","speed":3,"wwn":"aabbccddeeff0011","firmware_version":"2.2.2"}`),
+						Component: &FibreChannelCard{1, BaseObject{1}, "Generic FC Card", "4 Gbit", "aabbccddeeff0011", "2.2.2"},
+						Old:       &FibreChannelCard{1, BaseObject{1}, "Generic FC Card", "4 Gbit", "aabbccddeeff0011", "1.1.1"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
 	}
 	for tn, tc := range cases {
 		got, err := CompareFibreChannelCards(tc.fccOld, tc.fccNew)
@@ -936,15 +1116,15 @@ func TestCompareProcessors(t *testing.T) {
 		"#1 Create": {
 			procsOld: []*Processor{},
 			procsNew: []*Processor{
-				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{
 					&DiffComponent{
 						ID:        0,
 						Name:      "Processor",
-						Data:      []byte(`{"id":0,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8}`),
-						Component: &Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8,"socket":""}`),
+						Component: &Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 					},
 				},
 				Update: []*DiffComponent{},
@@ -953,11 +1133,11 @@ func TestCompareProcessors(t *testing.T) {
 		},
 		"#2 Delete (old > new && new > 0)": {
 			procsOld: []*Processor{
-				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
-				&Processor{2, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+				&Processor{2, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			},
 			procsNew: []*Processor{
-				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{},
@@ -966,15 +1146,15 @@ func TestCompareProcessors(t *testing.T) {
 					&DiffComponent{
 						ID:        1,
 						Name:      "Processor",
-						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8}`),
-						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8,"socket":""}`),
+						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 					},
 				},
 			},
 		},
 		"#3 Delete (old > new && new == 0)": {
 			procsOld: []*Processor{
-				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			},
 			procsNew: []*Processor{},
 			want: &Diff{
@@ -984,30 +1164,30 @@ func TestCompareProcessors(t *testing.T) {
 					&DiffComponent{
 						ID:        1,
 						Name:      "Processor",
-						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8}`),
-						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8,"socket":""}`),
+						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 					},
 				},
 			},
 		},
 		// Note that we test "Delete and Create" scenario instead of "Update" -
-		// in case of Processor, the latter doesn't make sense because Processor
-		// instances are not unique (in contrast to e.g. Ethernet, whose
-		// instances can be distinguished by their MACAddresses).
+		// in case of Processor without socket, the latter doesn't make sense because
+		// such Processor instances are not unique (in contrast to e.g. Ethernet,
+		// whose instances can be distinguished by their MACAddresses).
 		"#4 Update by \"Delete and Create\"": {
 			procsOld: []*Processor{
-				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			},
 			procsNew: []*Processor{
-				&Processor{0, BaseObject{1}, "Generic Processor", 2400, 4},
+				&Processor{0, BaseObject{1}, "Generic Processor", 2400, 4, ""},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{
 					&DiffComponent{
 						ID:        0,
 						Name:      "Processor",
-						Data:      []byte(`{"id":0,"base_object":1,"model_name":"Generic Processor","speed":2400,"cores":4}`),
-						Component: &Processor{0, BaseObject{1}, "Generic Processor", 2400, 4},
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"Generic Processor","speed":2400,"cores":4,"socket":""}`),
+						Component: &Processor{0, BaseObject{1}, "Generic Processor", 2400, 4, ""},
 					},
 				},
 				Update: []*DiffComponent{},
@@ -1015,18 +1195,52 @@ func TestCompareProcessors(t *testing.T) {
 					&DiffComponent{
 						ID:        1,
 						Name:      "Processor",
-						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8}`),
-						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R)","speed":2600,"cores":8,"socket":""}`),
+						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 					},
 				},
 			},
 		},
 		"#5 Don't do anything (both new and old Processor is the same)": {
 			procsOld: []*Processor{
-				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			},
+			procsNew: []*Processor{
+				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#6 Update (matched by socket)": {
+			procsOld: []*Processor{
+				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, "CPU.Socket.1"},
+			},
+			procsNew: []*Processor{
+				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R) Gold", 2800, 16, "CPU.Socket.1"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "Processor",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Intel(R) Xeon(R) Gold","speed":2800,"cores":16,"socket":"CPU.Socket.1"}`),
+						Component: &Processor{1, BaseObject{1}, "Intel(R) Xeon(R) Gold", 2800, 16, "CPU.Socket.1"},
+						Old:       &Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, "CPU.Socket.1"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#7 Don't do anything (socket missing in Ralph)": {
+			procsOld: []*Processor{
+				&Processor{1, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, ""},
 			},
 			procsNew: []*Processor{
-				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8},
+				&Processor{0, BaseObject{1}, "Intel(R) Xeon(R)", 2600, 8, "CPU.Socket.1"},
 			},
 			want: &Diff{
 				Create: []*DiffComponent{},
@@ -1123,9 +1337,9 @@ func TestCompareDisks(t *testing.T) {
 			},
 		},
 		// Note that we test "Delete and Create" scenario instead of "Update" -
-		// in case of Disk, the latter doesn't make sense because Disk
-		// instances are not unique (in contrast to e.g. Ethernet, whose
-		// instances can be distinguished by their MACAddresses).
+		// in case of Disk, the latter doesn't make sense when the serial
+		// number has changed, because it means that the disk has been
+		// replaced (even though the slot is the same).
 		"#4 Update by \"Delete and Create\"": {
 			disksOld: []*Disk{
 				&Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 1, "1.1.1"},
@@ -1166,6 +1380,66 @@ func TestCompareDisks(t *testing.T) {
 				Delete: []*DiffComponent{},
 			},
 		},
+		"#6 Update (matched by serial number)": {
+			disksOld: []*Disk{
+				&Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 1, "1.1.1"},
+				&Disk{2, BaseObject{1}, "ATA Samsung SSD 840", 476, "S5678", 2, "1.1.1"},
+			},
+			disksNew: []*Disk{
+				&Disk{0, BaseObject{1}, "ATA Samsung SSD 840", 476, "S5678", 2, "1.1.1"},
+				&Disk{0, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 3, "2.2.2"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "Disk",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"ATA Samsung SSD 840","size":476,"serial_number":"S1234","slot":3,"firmware_version":"2.2.2"}`),
+						Component: &Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 3, "2.2.2"},
+						Old:       &Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 1, "1.1.1"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#7 Update (matched by slot, when serial number is missing)": {
+			disksOld: []*Disk{
+				&Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "", 1, "1.1.1"},
+				&Disk{2, BaseObject{1}, "ATA Samsung SSD 840", 476, "", -1, "1.1.1"},
+			},
+			disksNew: []*Disk{
+				&Disk{0, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 1, "2.2.2"},
+				&Disk{0, BaseObject{1}, "ATA Samsung SSD 840", 476, "", -1, "2.2.2"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{
+					&DiffComponent{
+						ID:        0,
+						Name:      "Disk",
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"ATA Samsung SSD 840","size":476,"serial_number":"","slot":null,"firmware_version":"2.2.2"}`),
+						Component: &Disk{0, BaseObject{1}, "ATA Samsung SSD 840", 476, "", -1, "2.2.2"},
+					},
+				},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "Disk",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"ATA Samsung SSD 840","size":476,"serial_number":"S1234","slot":1,"firmware_version":"2.2.2"}`),
+						Component: &Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "S1234", 1, "2.2.2"},
+						Old:       &Disk{1, BaseObject{1}, "ATA Samsung SSD 840", 476, "", 1, "1.1.1"},
+					},
+				},
+				Delete: []*DiffComponent{
+					&DiffComponent{
+						ID:        2,
+						Name:      "Disk",
+						Data:      []byte(`{"id":2,"base_object":1,"model_name":"ATA Samsung SSD 840","size":476,"serial_number":"","slot":null,"firmware_version":"1.1.1"}`),
+						Component: &Disk{2, BaseObject{1}, "ATA Samsung SSD 840", 476, "", -1, "1.1.1"},
+					},
+				},
+			},
+		},
 	}
 	for tn, tc := range cases {
 		got, err := CompareDisks(tc.disksOld, tc.disksNew)
@@ -1178,6 +1452,291 @@ func TestCompareDisks(t *testing.T) {
 	}
 }
 
+func TestCompareComponents(t *testing.T) {
+	var cases = map[string]struct {
+		descriptor componentDescriptor
+		old        []Component
+		new        []Component
+		want       *Diff
+	}{
+		"#0 Empty diff": {
+			descriptor: powerSupplyDescriptor,
+			old:        []Component{},
+			new:        []Component{},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#1 PowerSupply: create and delete": {
+			descriptor: powerSupplyDescriptor,
+			old: []Component{
+				&PowerSupply{1, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.1D.7D"},
+			},
+			new: []Component{
+				&PowerSupply{0, BaseObject{1}, "PWR SPLY,750W,RDNT,DELTA", 750, "CN1797255J0R1K", "00.24.7D"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{
+					&DiffComponent{
+						ID:        0,
+						Name:      "PowerSupply",
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"PWR SPLY,750W,RDNT,DELTA","wattage":750,"serial_number":"CN1797255J0R1K","firmware_version":"00.24.7D"}`),
+						Component: &PowerSupply{0, BaseObject{1}, "PWR SPLY,750W,RDNT,DELTA", 750, "CN1797255J0R1K", "00.24.7D"},
+					},
+				},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "PowerSupply",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"PWR SPLY,495W,RDNT,DELTA","wattage":495,"serial_number":"CN1797255J0Q9J","firmware_version":"00.1D.7D"}`),
+						Component: &PowerSupply{1, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.1D.7D"},
+					},
+				},
+			},
+		},
+		"#2 PowerSupply: update (matched by serial number)": {
+			descriptor: powerSupplyDescriptor,
+			old: []Component{
+				&PowerSupply{1, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.1D.7D"},
+			},
+			new: []Component{
+				&PowerSupply{0, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.24.7D"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "PowerSupply",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"PWR SPLY,495W,RDNT,DELTA","wattage":495,"serial_number":"CN1797255J0Q9J","firmware_version":"00.24.7D"}`),
+						Component: &PowerSupply{1, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.24.7D"},
+						Old:       &PowerSupply{1, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.1D.7D"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#3 PowerSupply: no changes": {
+			descriptor: powerSupplyDescriptor,
+			old: []Component{
+				&PowerSupply{1, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.1D.7D"},
+			},
+			new: []Component{
+				&PowerSupply{0, BaseObject{1}, "PWR SPLY,495W,RDNT,DELTA", 495, "CN1797255J0Q9J", "00.1D.7D"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#4 StorageController: create and delete": {
+			descriptor: storageControllerDescriptor,
+			old: []Component{
+				&StorageController{1, BaseObject{1}, "PERC H730 Mini", "25.5.5.0005", 1024},
+			},
+			new: []Component{
+				&StorageController{0, BaseObject{1}, "PERC H740P Mini", "51.13.0-3485", 8192},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{
+					&DiffComponent{
+						ID:        0,
+						Name:      "StorageController",
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"PERC H740P Mini","firmware_version":"51.13.0-3485","cache_size":8192}`),
+						Component: &StorageController{0, BaseObject{1}, "PERC H740P Mini", "51.13.0-3485", 8192},
+					},
+				},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "StorageController",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"PERC H730 Mini","firmware_version":"25.5.5.0005","cache_size":1024}`),
+						Component: &StorageController{1, BaseObject{1}, "PERC H730 Mini", "25.5.5.0005", 1024},
+					},
+				},
+			},
+		},
+		"#5 StorageController: update (matched by model)": {
+			descriptor: storageControllerDescriptor,
+			old: []Component{
+				&StorageController{1, BaseObject{1}, "PERC H730 Mini", "25.5.5.0005", 1024},
+			},
+			new: []Component{
+				&StorageController{0, BaseObject{1}, "PERC H730 Mini", "25.5.9.0001", 1024},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "StorageController",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"PERC H730 Mini","firmware_version":"25.5.9.0001","cache_size":1024}`),
+						Component: &StorageController{1, BaseObject{1}, "PERC H730 Mini", "25.5.9.0001", 1024},
+						Old:       &StorageController{1, BaseObject{1}, "PERC H730 Mini", "25.5.5.0005", 1024},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#6 StorageController: no changes": {
+			descriptor: storageControllerDescriptor,
+			old: []Component{
+				&StorageController{1, BaseObject{1}, "PERC H730 Mini", "25.5.5.0005", 1024},
+			},
+			new: []Component{
+				&StorageController{0, BaseObject{1}, "PERC H730 Mini", "25.5.5.0005", 1024},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#7 GPU: create and delete": {
+			descriptor: gpuDescriptor,
+			old: []Component{
+				&GPU{1, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.43.00.03"},
+			},
+			new: []Component{
+				&GPU{0, BaseObject{1}, "NVIDIA Tesla T4", "1321019012345", "90.04.38.00.03"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{
+					&DiffComponent{
+						ID:        0,
+						Name:      "GPU",
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"NVIDIA Tesla T4","serial_number":"1321019012345","firmware_version":"90.04.38.00.03"}`),
+						Component: &GPU{0, BaseObject{1}, "NVIDIA Tesla T4", "1321019012345", "90.04.38.00.03"},
+					},
+				},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "GPU",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"NVIDIA Tesla V100","serial_number":"0323118012345","firmware_version":"88.00.43.00.03"}`),
+						Component: &GPU{1, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.43.00.03"},
+					},
+				},
+			},
+		},
+		"#8 GPU: update (matched by serial number)": {
+			descriptor: gpuDescriptor,
+			old: []Component{
+				&GPU{1, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.43.00.03"},
+			},
+			new: []Component{
+				&GPU{0, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.80.00.04"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "GPU",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"NVIDIA Tesla V100","serial_number":"0323118012345","firmware_version":"88.00.80.00.04"}`),
+						Component: &GPU{1, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.80.00.04"},
+						Old:       &GPU{1, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.43.00.03"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#9 GPU: no changes": {
+			descriptor: gpuDescriptor,
+			old: []Component{
+				&GPU{1, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.43.00.03"},
+			},
+			new: []Component{
+				&GPU{0, BaseObject{1}, "NVIDIA Tesla V100", "0323118012345", "88.00.43.00.03"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#10 NVMeDrive: create and delete": {
+			descriptor: nvmeDriveDescriptor,
+			old: []Component{
+				&NVMeDrive{1, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.0.4"},
+			},
+			new: []Component{
+				&NVMeDrive{0, BaseObject{1}, "Dell Express Flash PM1725b 3.2TB", 2980, "S4ENNF0M800456", "1.1.0"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{
+					&DiffComponent{
+						ID:        0,
+						Name:      "NVMeDrive",
+						Data:      []byte(`{"id":0,"base_object":1,"model_name":"Dell Express Flash PM1725b 3.2TB","size":2980,"serial_number":"S4ENNF0M800456","firmware_version":"1.1.0"}`),
+						Component: &NVMeDrive{0, BaseObject{1}, "Dell Express Flash PM1725b 3.2TB", 2980, "S4ENNF0M800456", "1.1.0"},
+					},
+				},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "NVMeDrive",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Dell Express Flash PM1725a 1.6TB","size":1490,"serial_number":"S39XNX0J500123","firmware_version":"1.0.4"}`),
+						Component: &NVMeDrive{1, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.0.4"},
+					},
+				},
+			},
+		},
+		"#11 NVMeDrive: update (matched by serial number)": {
+			descriptor: nvmeDriveDescriptor,
+			old: []Component{
+				&NVMeDrive{1, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.0.4"},
+			},
+			new: []Component{
+				&NVMeDrive{0, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.1.0"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{
+					&DiffComponent{
+						ID:        1,
+						Name:      "NVMeDrive",
+						Data:      []byte(`{"id":1,"base_object":1,"model_name":"Dell Express Flash PM1725a 1.6TB","size":1490,"serial_number":"S39XNX0J500123","firmware_version":"1.1.0"}`),
+						Component: &NVMeDrive{1, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.1.0"},
+						Old:       &NVMeDrive{1, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.0.4"},
+					},
+				},
+				Delete: []*DiffComponent{},
+			},
+		},
+		"#12 NVMeDrive: no changes": {
+			descriptor: nvmeDriveDescriptor,
+			old: []Component{
+				&NVMeDrive{1, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.0.4"},
+			},
+			new: []Component{
+				&NVMeDrive{0, BaseObject{1}, "Dell Express Flash PM1725a 1.6TB", 1490, "S39XNX0J500123", "1.0.4"},
+			},
+			want: &Diff{
+				Create: []*DiffComponent{},
+				Update: []*DiffComponent{},
+				Delete: []*DiffComponent{},
+			},
+		},
+	}
+	for tn, tc := range cases {
+		got, err := compareComponents(tc.descriptor, tc.old, tc.new)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if eq, err := checkers.DeepEqual(*got, *tc.want); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
+
 func TestGetEthernets(t *testing.T) {
 	var cases = []struct {
 		file    string
@@ -1377,6 +1936,86 @@ func TestGetDisks(t *testing.T) {
 	}
 }
 
+func TestGetComponents(t *testing.T) {
+	var cases = map[string]struct {
+		file          string
+		componentType string
+		want          interface{} // slice of pointers, e.g. []*PowerSupply
+	}{
+		"#0 PowerSupply": {
+			"power_supply_components.json",
+			"PowerSupply",
+			[]*PowerSupply{
+				&PowerSupply{
+					ID:              1,
+					BaseObject:      BaseObject{1},
+					ModelName:       "PWR SPLY,495W,RDNT,DELTA",
+					Wattage:         495,
+					SerialNumber:    "CN1797255J0Q9J",
+					FirmwareVersion: "00.1D.7D",
+				},
+			},
+		},
+		"#1 StorageController": {
+			"storage_controller_components.json",
+			"StorageController",
+			[]*StorageController{
+				&StorageController{
+					ID:              1,
+					BaseObject:      BaseObject{1},
+					ModelName:       "PERC H730 Mini",
+					FirmwareVersion: "25.5.5.0005",
+					CacheSize:       1024,
+				},
+			},
+		},
+		"#2 GPU": {
+			"gpu_components.json",
+			"GPU",
+			[]*GPU{
+				&GPU{
+					ID:              1,
+					BaseObject:      BaseObject{1},
+					ModelName:       "NVIDIA Tesla V100",
+					SerialNumber:    "0323118012345",
+					FirmwareVersion: "88.00.43.00.03",
+				},
+			},
+		},
+		"#3 NVMeDrive": {
+			"nvme_drive_components.json",
+			"NVMeDrive",
+			[]*NVMeDrive{
+				&NVMeDrive{
+					ID:              1,
+					BaseObject:      BaseObject{1},
+					ModelName:       "Dell Express Flash PM1725a 1.6TB",
+					Size:            1490,
+					SerialNumber:    "S39XNX0J500123",
+					FirmwareVersion: "1.0.4",
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		fixture, err := LoadFixture(ralphTestFixturesDir, tc.file)
+		if err != nil {
+			t.Fatalf("file: %s\n%s", tc.file, err)
+		}
+		server, client := MockServerClient(200, fixture)
+		defer server.Close()
+
+		got := reflect.New(reflect.TypeOf(tc.want))
+		if err := (BaseObject{1}).getComponents(client, tc.componentType, got.Interface()); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if eq, err := checkers.DeepEqual(got.Elem().Interface(), tc.want); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
+
 func TestGetDataCenterAsset(t *testing.T) {
 	var cases = []struct {
 		file    string
@@ -1439,7 +2078,7 @@ func TestMemoryToString(t *testing.T) {
 		Size:       16384,
 		Speed:      1600,
 	}
-	want := `Memory{id: 1, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 16384, speed: 1600}`
+	want := `Memory{id: 1, base_object_id: 1, model_name: Samsung DDR3 DIMM, size: 16384, speed: 1600, slot: }`
 
 	got := memory.String()
 	if got != want {
@@ -1472,7 +2111,7 @@ func TestProcessorToString(t *testing.T) {
 		Speed:      2600,
 		Cores:      8,
 	}
-	want := `Processor{id: 1, base_object_id: 1, model_name: Intel(R) Xeon(R), speed: 2600, cores: 8}`
+	want := `Processor{id: 1, base_object_id: 1, model_name: Intel(R) Xeon(R), speed: 2600, cores: 8, socket: }`
 
 	got := proc.String()
 	if got != want {
@@ -1519,6 +2158,72 @@ func TestDiskToString(t *testing.T) {
 	}
 }
 
+func TestPowerSupplyToString(t *testing.T) {
+	psu := PowerSupply{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "PWR SPLY,495W,RDNT,DELTA",
+		Wattage:         495,
+		SerialNumber:    "CN1797255J0Q9J",
+		FirmwareVersion: "00.1D.7D",
+	}
+	want := `PowerSupply{id: 1, base_object_id: 1, model_name: PWR SPLY,495W,RDNT,DELTA, wattage: 495, serial_number: CN1797255J0Q9J, firmware_version: 00.1D.7D}`
+
+	got := psu.String()
+	if got != want {
+		t.Errorf("\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestStorageControllerToString(t *testing.T) {
+	ctrl := StorageController{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "PERC H730 Mini",
+		FirmwareVersion: "25.5.5.0005",
+		CacheSize:       1024,
+	}
+	want := `StorageController{id: 1, base_object_id: 1, model_name: PERC H730 Mini, firmware_version: 25.5.5.0005, cache_size: 1024}`
+
+	got := ctrl.String()
+	if got != want {
+		t.Errorf("\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestGPUToString(t *testing.T) {
+	gpu := GPU{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "NVIDIA Tesla V100",
+		SerialNumber:    "0323118012345",
+		FirmwareVersion: "88.00.43.00.03",
+	}
+	want := `GPU{id: 1, base_object_id: 1, model_name: NVIDIA Tesla V100, serial_number: 0323118012345, firmware_version: 88.00.43.00.03}`
+
+	got := gpu.String()
+	if got != want {
+		t.Errorf("\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestNVMeDriveToString(t *testing.T) {
+	drive := NVMeDrive{
+		ID:              1,
+		BaseObject:      BaseObject{1},
+		ModelName:       "Dell Express Flash PM1725a 1.6TB",
+		Size:            1490,
+		SerialNumber:    "S39XNX0J500123",
+		FirmwareVersion: "1.0.4",
+	}
+	want := `NVMeDrive{id: 1, base_object_id: 1, model_name: Dell Express Flash PM1725a 1.6TB, size: 1490, serial_number: S39XNX0J500123, firmware_version: 1.0.4}`
+
+	got := drive.String()
+	if got != want {
+		t.Errorf("\n got: %v\nwant: %v", got, want)
+	}
+}
+
 func TestDataCenterAssetToString(t *testing.T) {
 	var cases = map[string]struct {
 		dcAsset DataCenterAsset
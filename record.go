@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Names of files holding a recording of a scan of a single host (see
+// ScanOpts.RecordDir and ReplayScan). Each host has its own dir, named after
+// its address.
+const (
+	recordingMetaFile      = "recording.json"
+	recordingStdoutFile    = "script_stdout"
+	recordingStderrFile    = "script_stderr"
+	recordingEnvFile       = "script_env.json"
+	recordingContextFile   = "script_context.json"
+	recordingResponsesFile = "ralph_responses.json"
+)
+
+// redacted replaces secrets in recordings.
+const redacted = "[redacted]"
+
+// recordingMeta describes a recording of a scan of a single host, along with
+// the settings it was performed with (so it can be replayed in the same way).
+type recordingMeta struct {
+	Host                Addr           `json:"host"`
+	RalphAPIURL         string         `json:"ralph_api_url"`
+	Script              string         `json:"script,omitempty"`
+	ScriptError         string         `json:"script_error,omitempty"` // error returned by the script (if any)
+	Error               *recordedError `json:"error,omitempty"`        // error which aborted the scan before running the script (if any)
+	Components          []string       `json:"components"`
+	WithBIOSAndFirmware bool           `json:"with_bios_and_firmware"`
+	WithModel           bool           `json:"with_model"`
+}
+
+// recordedError is ScanError which aborted a recorded scan before running the
+// script (e.g. when looking up the host in Ralph, or resolving credentials).
+type recordedError struct {
+	Component string    `json:"component,omitempty"`
+	Stage     ScanStage `json:"stage"`
+	Message   string    `json:"message"`
+}
+
+// recordedResponse is a response to a GET request sent to Ralph.
+type recordedResponse struct {
+	Path   string `json:"path"` // relative to Ralph API URL, along with the query
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+// hostRecorder records a scan of a single host in dir. It's also used by Client
+// (as http.RoundTripper) for recording Ralph's responses to GET requests (other
+// requests are passed through, but not recorded).
+type hostRecorder struct {
+	dir       string
+	meta      recordingMeta
+	transport http.RoundTripper
+
+	scriptRun bool // whether the script has been run (see recordScript)
+
+	mu        sync.Mutex
+	responses []recordedResponse
+}
+
+// newHostRecorder creates hostRecorder for a scan of addr performed with opts
+// and cfg, along with its dir (i.e. addr subdir of opts.RecordDir). Since even
+// redacted recordings contain data which shouldn't be available to everyone,
+// this dir is readable only by its owner.
+func newHostRecorder(addr Addr, opts *ScanOpts, cfg *Config) (*hostRecorder, error) {
+	dir := filepath.Join(opts.RecordDir, string(addr))
+	if err := os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return nil, fmt.Errorf("error creating recording dir: %v", err)
+	}
+	r := &hostRecorder{
+		dir: dir,
+		meta: recordingMeta{
+			Host:                addr,
+			RalphAPIURL:         cfg.RalphAPIURL,
+			Components:          opts.scriptParams().Components,
+			WithBIOSAndFirmware: opts.WithBIOSAndFirmware,
+			WithModel:           opts.WithModel,
+		},
+		transport: http.DefaultTransport,
+	}
+	if opts.Script != nil {
+		r.meta.Script = filepath.Base(opts.Script.Path)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *hostRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil || req.Method != "GET" {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, recordedResponse{
+		Path:   strings.TrimPrefix(req.URL.String(), r.meta.RalphAPIURL),
+		Status: resp.StatusCode,
+		Body:   string(body),
+	})
+	return resp, nil
+}
+
+// recordScript records a single run of a scan script (see Script.Run): its
+// environment and context (with secrets redacted), its output, and the error
+// returned by it (if any).
+func (r *hostRecorder) recordScript(env []string, sc ScriptContext, cfg *Config, stdout, stderr []byte, scriptErr error) error {
+	r.scriptRun = true
+	sc.ManagementUserPassword = redacted
+	if scriptErr != nil {
+		r.meta.ScriptError = scriptErr.Error()
+	}
+	envData, err := json.MarshalIndent(redactEnv(env, cfg.ManagementUserPassword, cfg.RalphAPIKey), "", "    ")
+	if err != nil {
+		return err
+	}
+	contextData, err := json.MarshalIndent(sc, "", "    ")
+	if err != nil {
+		return err
+	}
+	var files = []struct {
+		name string
+		data []byte
+	}{
+		{recordingEnvFile, envData},
+		{recordingContextFile, contextData},
+		{recordingStdoutFile, stdout},
+		{recordingStderrFile, stderr},
+	}
+	for _, f := range files {
+		if err := ioutil.WriteFile(filepath.Join(r.dir, f.name), f.data, os.FileMode(0600)); err != nil {
+			return fmt.Errorf("error recording script run: %v", err)
+		}
+	}
+	return nil
+}
+
+// recordError records err (returned by PerformScan), unless the script has
+// been run already - in such case, the scan can be replayed from the script's
+// output, so there's no need to record anything more.
+func (r *hostRecorder) recordError(err error) {
+	if r.scriptRun {
+		return
+	}
+	e := &recordedError{Stage: StageScript, Message: err.Error()}
+	if scanErr, ok := err.(*ScanError); ok {
+		e = &recordedError{scanErr.Component, scanErr.Stage, scanErr.Err.Error()}
+	}
+	r.meta.Error = e
+}
+
+// save writes recordingMeta and recorded responses from Ralph to r.dir.
+func (r *hostRecorder) save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	responses := r.responses
+	if responses == nil {
+		responses = []recordedResponse{}
+	}
+	for name, v := range map[string]interface{}{
+		recordingMetaFile:      r.meta,
+		recordingResponsesFile: responses,
+	} {
+		data, err := json.MarshalIndent(v, "", "    ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(r.dir, name), data, os.FileMode(0600)); err != nil {
+			return fmt.Errorf("error saving recording: %v", err)
+		}
+	}
+	return nil
+}
+
+// redactEnv returns env (in "NAME=value" form) with values of variables which
+// look like secrets (judging by their names), or which are equal to one of the
+// secrets, replaced with a placeholder.
+func redactEnv(env []string, secrets ...string) []string {
+	var redactedEnv []string
+	for _, e := range env {
+		pair := strings.SplitN(e, "=", 2)
+		name := strings.ToUpper(pair[0])
+		secret := strings.Contains(name, "PASSWORD") || strings.Contains(name, "SECRET") ||
+			strings.Contains(name, "TOKEN") || strings.Contains(name, "API_KEY")
+		for _, s := range secrets {
+			if len(pair) == 2 && s != "" && pair[1] == s {
+				secret = true
+			}
+		}
+		if secret {
+			e = fmt.Sprintf("%s=%s", pair[0], redacted)
+		}
+		redactedEnv = append(redactedEnv, e)
+	}
+	return redactedEnv
+}
+
+// RecordedHosts returns addresses of hosts, whose scans are recorded in dir
+// (see ScanOpts.RecordDir).
+func RecordedHosts(dir string) ([]Addr, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading recordings: %v", err)
+	}
+	var addrs []Addr
+	for _, f := range files {
+		if f.IsDir() && fileExists(filepath.Join(dir, f.Name(), recordingMetaFile)) {
+			addrs = append(addrs, Addr(f.Name()))
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no recordings found in %s", dir)
+	}
+	return addrs, nil
+}
+
+// ReplayScan performs a scan of addr offline (see PerformScan), using its
+// recording stored in dir (see ScanOpts.RecordDir): the recorded output of the
+// script is used instead of running it (and its stderr is logged, as if it was
+// running), and Ralph is replaced with a fake one (see fakeRalph). Components
+// etc. are taken from the recording, while the remaining settings (e.g. bulk
+// or dry-run mode) are taken from opts.
+func ReplayScan(addr Addr, dir string, opts *ScanOpts, cfg *Config) (bool, error) {
+	var meta recordingMeta
+	var responses []recordedResponse
+	if err := readRecordingFile(dir, recordingMetaFile, &meta); err != nil {
+		return false, NewScanError(addr, "", StageScript, err)
+	}
+	if err := readRecordingFile(dir, recordingResponsesFile, &responses); err != nil {
+		return false, NewScanError(addr, "", StageLookup, err)
+	}
+	hostOpts := *opts
+	hostOpts.Script = nil
+	hostOpts.RecordDir = ""
+	hostOpts.Components = map[string]bool{"none": len(meta.Components) == 0}
+	for _, c := range meta.Components {
+		hostOpts.Components[c] = true
+	}
+	hostOpts.WithBIOSAndFirmware = meta.WithBIOSAndFirmware
+	hostOpts.WithModel = meta.WithModel
+	hostOpts.ralphTransport = newFakeRalph(meta.RalphAPIURL, responses)
+
+	hostCfg := *cfg
+	hostCfg.RalphAPIURL = meta.RalphAPIURL
+	// Fake Ralph doesn't check the API key, so there's no point in resolving
+	// it (see NewClient).
+	hostCfg.RalphAPIKey = ""
+
+	if meta.Error != nil {
+		// The scan was aborted before running the script, so the replay ends
+		// in the same way.
+		return false, NewScanError(addr, meta.Error.Component, meta.Error.Stage, errors.New(meta.Error.Message))
+	}
+	if meta.ScriptError != "" {
+		return false, NewScanError(addr, "", StageScript, errors.New(meta.ScriptError))
+	}
+	stdout, err := ioutil.ReadFile(filepath.Join(dir, recordingStdoutFile))
+	if err != nil {
+		return false, NewScanError(addr, "", StageScript, fmt.Errorf("error reading recording: %v", err))
+	}
+	stderr, err := ioutil.ReadFile(filepath.Join(dir, recordingStderrFile))
+	if err != nil {
+		return false, NewScanError(addr, "", StageScript, fmt.Errorf("error reading recording: %v", err))
+	}
+	logWriter := &lineLogger{prefix: scriptLogPrefix(meta.Script, addr)}
+	logWriter.Write(stderr)
+	logWriter.Flush()
+	hostOpts.Result, err = parseScanResult(stdout, fmt.Sprintf("recorded output of script %s", meta.Script))
+	if err != nil {
+		return false, NewScanError(addr, "", StageScript, fmt.Errorf("error unmarshaling script output: %s", err))
+	}
+	hostOpts.Result.Diagnostics = string(stderr)
+
+	return PerformScan(addr, &hostOpts, &hostCfg)
+}
+
+// readRecordingFile decodes JSON from a file given as name (in dir) into v.
+func readRecordingFile(dir, name string, v interface{}) error {
+	data, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return fmt.Errorf("error reading recording: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error unmarshaling %s: %v", filepath.Join(dir, name), err)
+	}
+	return nil
+}
+
+// fakeRalph is an in-process replacement for Ralph (used as http.RoundTripper
+// by Client), which serves recorded responses to GET requests (in the order in
+// which they were recorded, when the same path was requested many times), and
+// accepts all the other requests (e.g. POST or PATCH), logging them.
+type fakeRalph struct {
+	ralphURL string
+
+	mu        sync.Mutex
+	responses map[string][]recordedResponse
+	nextID    int // for objects "created" with POST requests
+}
+
+// newFakeRalph creates fakeRalph serving responses recorded for ralphURL.
+func newFakeRalph(ralphURL string, responses []recordedResponse) *fakeRalph {
+	f := &fakeRalph{
+		ralphURL:  ralphURL,
+		responses: make(map[string][]recordedResponse),
+		nextID:    100000,
+	}
+	for _, r := range responses {
+		f.responses[r.Path] = append(f.responses[r.Path], r)
+	}
+	return f
+}
+
+// RoundTrip implements http.RoundTripper.
+func (f *fakeRalph) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	path := strings.TrimPrefix(req.URL.String(), f.ralphURL)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch req.Method {
+	case "GET":
+		rr := f.responses[path]
+		if len(rr) == 0 {
+			log.Printf("WARNING: No recorded response for GET %s.", path)
+			return fakeResponse(req, http.StatusNotFound, `{"detail": "Not found."}`), nil
+		}
+		// The last response is repeated for all the subsequent requests.
+		if len(rr) > 1 {
+			f.responses[path] = rr[1:]
+		}
+		return fakeResponse(req, rr[0].Status, rr[0].Body), nil
+	case "POST":
+		log.Printf("Replay: %s %s %s", req.Method, path, reqBody)
+		f.nextID++
+		return fakeResponse(req, http.StatusCreated, fmt.Sprintf(`{"id": %d}`, f.nextID)), nil
+	case "DELETE":
+		log.Printf("Replay: %s %s", req.Method, path)
+		return fakeResponse(req, http.StatusNoContent, ""), nil
+	default:
+		log.Printf("Replay: %s %s %s", req.Method, path, reqBody)
+		return fakeResponse(req, http.StatusOK, string(reqBody)), nil
+	}
+}
+
+// fakeResponse returns http.Response for req, with a given status and body.
+func fakeResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/juju/testing/checkers"
+)
+
+func TestRedactEnv(t *testing.T) {
+	var cases = map[string]struct {
+		env     []string
+		secrets []string
+		want    []string
+	}{
+		"#0 Variables with secret-like names are redacted": {
+			[]string{"PATH=/usr/bin", "RALPH_CLI_MANAGEMENT_USER_PASSWORD=xxx", "SOME_API_KEY=yyy"},
+			nil,
+			[]string{"PATH=/usr/bin", "RALPH_CLI_MANAGEMENT_USER_PASSWORD=[redacted]", "SOME_API_KEY=[redacted]"},
+		},
+		"#1 Variables holding given secrets are redacted": {
+			[]string{"PATH=/usr/bin", "FOO=s3cret", "BAR=other"},
+			[]string{"s3cret", ""},
+			[]string{"PATH=/usr/bin", "FOO=[redacted]", "BAR=other"},
+		},
+		"#2 Empty secrets don't redact empty values": {
+			[]string{"EMPTY=", "NO_VALUE"},
+			[]string{""},
+			[]string{"EMPTY=", "NO_VALUE"},
+		},
+	}
+
+	for tn, tc := range cases {
+		got := redactEnv(tc.env, tc.secrets...)
+		if !TestEqStr(got, tc.want) {
+			t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
+		}
+	}
+}
+
+func TestFakeRalph(t *testing.T) {
+	ralphURL := "http://ralph.local/api"
+	f := newFakeRalph(ralphURL, []recordedResponse{
+		{"/data-center-assets/?id=1", 200, `{"id": 1}`},
+		{"/ethernets/?base_object=1", 200, `first`},
+		{"/ethernets/?base_object=1", 200, `second`},
+	})
+	httpClient := &http.Client{Transport: f}
+	get := func(path string) (int, string) {
+		resp, err := httpClient.Get(ralphURL + path)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	var cases = []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/data-center-assets/?id=1", 200, `{"id": 1}`},
+		{"/ethernets/?base_object=1", 200, `first`},
+		{"/ethernets/?base_object=1", 200, `second`},
+		// The last response is repeated.
+		{"/ethernets/?base_object=1", 200, `second`},
+		{"/memory/?base_object=1", 404, `{"detail": "Not found."}`},
+	}
+	for i, tc := range cases {
+		status, body := get(tc.path)
+		if status != tc.wantStatus || body != tc.wantBody {
+			t.Errorf("#%d\n got: %d %q\nwant: %d %q", i, status, body, tc.wantStatus, tc.wantBody)
+		}
+	}
+
+	for _, want := range []string{`{"id": 100001}`, `{"id": 100002}`} {
+		resp, err := httpClient.Post(ralphURL+"/memory/", "application/json", nil)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated || string(body) != want {
+			t.Errorf("POST\n got: %d %q\nwant: %d %q", resp.StatusCode, body, http.StatusCreated, want)
+		}
+	}
+}
+
+func TestHostRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "ralph-cli-test-")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := &Config{RalphAPIURL: server.URL + "/api", ManagementUserPassword: "s3cret"}
+	opts := &ScanOpts{
+		Components: map[string]bool{"eth": true, "mem": true},
+		WithModel:  true,
+		Script:     &Script{Path: "/some/dir/idrac.py"},
+		RecordDir:  dir,
+	}
+	rec, err := newHostRecorder(Addr("10.20.30.40"), opts, cfg)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	httpClient := &http.Client{Transport: rec}
+	resp, err := httpClient.Get(cfg.RalphAPIURL + "/ethernets/?base_object=1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+	env := []string{"PATH=/usr/bin", "RALPH_CLI_MANAGEMENT_USER_PASSWORD=s3cret"}
+	sc := ScriptContext{Host: "10.20.30.40", ManagementUserPassword: "s3cret"}
+	scriptErr := errors.New("some error")
+	if err := rec.recordScript(env, sc, cfg, []byte(`{}`), []byte("some diagnostics\n"), scriptErr); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := rec.save(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	hostDir := filepath.Join(dir, "10.20.30.40")
+	hosts, err := RecordedHosts(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if eq, err := checkers.DeepEqual(hosts, []Addr{"10.20.30.40"}); !eq {
+		t.Errorf("recorded hosts: %s", err)
+	}
+
+	var meta recordingMeta
+	if err := readRecordingFile(hostDir, recordingMetaFile, &meta); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	wantMeta := recordingMeta{
+		Host:        "10.20.30.40",
+		RalphAPIURL: cfg.RalphAPIURL,
+		Script:      "idrac.py",
+		ScriptError: "some error",
+		Components:  []string{"eth", "mem"},
+		WithModel:   true,
+	}
+	if eq, err := checkers.DeepEqual(meta, wantMeta); !eq {
+		t.Errorf("meta: %s", err)
+	}
+
+	var responses []recordedResponse
+	if err := readRecordingFile(hostDir, recordingResponsesFile, &responses); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	wantResponses := []recordedResponse{
+		{"/ethernets/?base_object=1", 200, `{"path": "/api/ethernets/"}`},
+	}
+	if eq, err := checkers.DeepEqual(responses, wantResponses); !eq {
+		t.Errorf("responses: %s", err)
+	}
+
+	var gotEnv []string
+	if err := readRecordingFile(hostDir, recordingEnvFile, &gotEnv); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	wantEnv := []string{"PATH=/usr/bin", "RALPH_CLI_MANAGEMENT_USER_PASSWORD=[redacted]"}
+	if !TestEqStr(gotEnv, wantEnv) {
+		t.Errorf("env\n got: %v\nwant: %v", gotEnv, wantEnv)
+	}
+
+	var gotContext ScriptContext
+	if err := readRecordingFile(hostDir, recordingContextFile, &gotContext); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if gotContext.ManagementUserPassword != redacted {
+		t.Errorf("context\n got password: %q\nwant: %q", gotContext.ManagementUserPassword, redacted)
+	}
+
+	fi, err := os.Stat(filepath.Join(hostDir, recordingStderrFile))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("stderr file\n got perm: %o\nwant: %o", perm, 0600)
+	}
+}
+
+func TestReplayAbortedScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, APIEndpoints["DataCenterAsset"]):
+			fmt.Fprintln(w, `{"id": 1}`)
+		default:
+			fmt.Fprintln(w, `{"count": 1, "results": [{"id": 1}]}`)
+		}
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "ralph-cli-test-")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The scan gets aborted before running the script (which doesn't even
+	// exist), since management credentials can't be resolved.
+	cfg := &Config{
+		RalphAPIURL: server.URL + "/api",
+		RalphAPIKey: "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+		Credentials: []Credential{
+			{Network: "10.0.0.0/8", ManagementUserPassword: "env:RALPH_CLI_TEST_DOES_NOT_EXIST"},
+		},
+	}
+	opts := &ScanOpts{
+		Components: map[string]bool{"eth": true},
+		Script:     &Script{Path: filepath.Join(dir, "does_not_exist.sh")},
+		RecordDir:  dir,
+	}
+	_, scanErr := PerformScan(Addr("10.20.30.40"), opts, cfg)
+	if scanErr == nil {
+		t.Fatalf("scan didn't fail")
+	}
+
+	_, replayErr := ReplayScan(Addr("10.20.30.40"), filepath.Join(dir, "10.20.30.40"), &ScanOpts{}, cfg)
+	if replayErr == nil || replayErr.Error() != scanErr.Error() {
+		t.Errorf("\n got: %v\nwant: %v", replayErr, scanErr)
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// contractEnvVars lists environment variables passed to scan scripts by
+// ralph-cli (see prepareEnv), which can't be overridden by Manifest.Env.
+var contractEnvVars = []string{
+	"IP_TO_SCAN",
+	"MANAGEMENT_USER_NAME",
+	"MANAGEMENT_USER_PASSWORD",
+	"RALPH_CLI_CONTRACT_VERSION",
+	"RALPH_CLI_COMPONENTS",
+	"RALPH_CLI_WITH_MODEL",
+	"RALPH_CLI_CONTEXT",
+}
+
+// CommandVars holds values available in templates given in Manifest (i.e. in
+// Command, Args and Env fields), e.g. "{{.Script}}".
+type CommandVars struct {
+	Script      string // path to the script
+	ScriptDir   string // dir holding the script (i.e. the scripts dir)
+	WorkDir     string // working dir of the script
+	Venv        string // path to the virtualenv (only for Python scripts)
+	Interpreter string // Interpreter from Manifest
+	Host        Addr   // address of the host being scanned
+}
+
+// expandTemplate executes tmpl (in text/template format) with vars.
+func expandTemplate(tmpl string, vars CommandVars) (string, error) {
+	t, err := template.New("").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// validateRuntime is a helper method for Manifest.validate, checking fields
+// related to launching the script (templates, environment variables etc.).
+// Unlike validate, it returns error messages, not ValidationError.
+func (m *Manifest) validateRuntime() []string {
+	var errMsgs []string
+	var tmpls = map[string]string{"Command": m.Command}
+	for i, a := range m.Args {
+		tmpls[fmt.Sprintf("Args[%d]", i)] = a
+	}
+	for k, v := range m.Env {
+		tmpls[fmt.Sprintf("Env.%s", k)] = v
+		switch {
+		case k == "" || strings.Contains(k, "="):
+			errMsgs = append(errMsgs, fmt.Sprintf("invalid name of environment variable: %q", k))
+		case isContractEnvVar(k):
+			errMsgs = append(errMsgs, fmt.Sprintf("environment variable %s can't be overridden in Env", k))
+		}
+	}
+	for name, tmpl := range tmpls {
+		if _, err := expandTemplate(tmpl, CommandVars{}); err != nil {
+			errMsgs = append(errMsgs, fmt.Sprintf("invalid template in %s: %v", name, err))
+		}
+	}
+	// Map iteration order is random.
+	sort.Strings(errMsgs)
+	return errMsgs
+}
+
+// isContractEnvVar returns true if name is one of contractEnvVars.
+func isContractEnvVar(name string) bool {
+	for _, n := range contractEnvVars {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// workDir returns the working dir of the script (as given in Manifest), or
+// an empty string when it should be inherited from ralph-cli.
+func (s Script) workDir() string {
+	if s.Manifest == nil || s.Manifest.WorkDir == "" {
+		return ""
+	}
+	if filepath.IsAbs(s.Manifest.WorkDir) {
+		return s.Manifest.WorkDir
+	}
+	return filepath.Join(filepath.Dir(s.Path), s.Manifest.WorkDir)
+}
+
+// packageDir returns the dir holding package.json of a Node.js script (i.e.
+// its working dir, or the scripts dir, if the former is not given).
+func (s Script) packageDir() string {
+	if dir := s.workDir(); dir != "" {
+		return dir
+	}
+	return filepath.Dir(s.Path)
+}
+
+// commandVars returns CommandVars for launching the script on host.
+func (s Script) commandVars(host Addr) CommandVars {
+	vars := CommandVars{
+		Script:    s.Path,
+		ScriptDir: filepath.Dir(s.Path),
+		WorkDir:   s.workDir(),
+		Host:      host,
+	}
+	if s.Manifest != nil {
+		vars.Interpreter = s.Manifest.Interpreter
+		if s.Manifest.Language == "python" {
+			vars.Venv = MakeVenvPath(s)
+		}
+	}
+	return vars
+}
+
+// command returns the program (along with its args) which launches the
+// script on host. It's the script itself, unless Manifest says otherwise:
+// Command (when given) is used with Args as its args; in other case, the
+// script is launched with Interpreter, or with the default program for its
+// Language (i.e. python from the virtualenv, "go run" or node), and Args are
+// appended to the script's path.
+func (s Script) command(host Addr) (name string, args []string, err error) {
+	m := s.Manifest
+	if m == nil {
+		return s.Path, nil, nil
+	}
+	vars := s.commandVars(host)
+	var extraArgs []string
+	for _, a := range m.Args {
+		arg, err := expandTemplate(a, vars)
+		if err != nil {
+			return "", nil, fmt.Errorf("error expanding Args from manifest %s: %v", m.Path, err)
+		}
+		extraArgs = append(extraArgs, arg)
+	}
+	if m.Command != "" {
+		if name, err = expandTemplate(m.Command, vars); err != nil {
+			return "", nil, fmt.Errorf("error expanding Command from manifest %s: %v", m.Path, err)
+		}
+		return name, extraArgs, nil
+	}
+	switch {
+	case m.Language == "python" && m.Interpreter == "":
+		name, args = filepath.Join(vars.Venv, "bin", "python"), []string{s.Path}
+	case m.Language == "go":
+		name, args = "go", []string{"run", s.Path}
+	case m.Language == "node":
+		name, args = "node", []string{s.Path}
+	case m.Interpreter == "":
+		name = s.Path
+	default:
+		args = []string{s.Path}
+	}
+	if m.Interpreter != "" {
+		name = m.Interpreter
+	}
+	return name, append(args, extraArgs...), nil
+}
+
+// env returns environment variables (in "NAME=value" form, sorted) which
+// should be passed to the script on top of the ones prepared by prepareEnv,
+// i.e. the ones from Manifest.Env, and NODE_PATH for Node.js scripts (unless
+// it's given in Manifest.Env).
+func (s Script) env(host Addr) ([]string, error) {
+	if s.Manifest == nil {
+		return nil, nil
+	}
+	vars := s.commandVars(host)
+	var env []string
+	for k, v := range s.Manifest.Env {
+		val, err := expandTemplate(v, vars)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding Env.%s from manifest %s: %v", k, s.Manifest.Path, err)
+		}
+		env = append(env, fmt.Sprintf("%s=%s", k, val))
+	}
+	if _, ok := s.Manifest.Env["NODE_PATH"]; !ok && s.Manifest.Language == "node" {
+		env = append(env, fmt.Sprintf("NODE_PATH=%s", filepath.Join(s.packageDir(), "node_modules")))
+	}
+	sort.Strings(env)
+	return env, nil
+}
+
+// nodeModulesStateFile is the name of a file (stored in node_modules dir)
+// holding the hash of package.json, for which these modules were installed.
+const nodeModulesStateFile = ".ralph-cli-package-hash"
+
+// PrepareNodeModules installs packages listed in package.json of a given
+// Node.js script (see Script.packageDir) with "npm install", when they were
+// not installed yet, or when package.json has changed since then. Returns true
+// if packages have been installed. Scripts without package.json are skipped.
+func PrepareNodeModules(s Script) (bool, error) {
+	dir := s.packageDir()
+	pkg, err := ioutil.ReadFile(filepath.Join(dir, "package.json"))
+	switch {
+	case os.IsNotExist(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("error reading package.json: %v", err)
+	}
+	sum := sha256.Sum256(pkg)
+	hash := hex.EncodeToString(sum[:])
+	stateFile := filepath.Join(dir, "node_modules", nodeModulesStateFile)
+	if state, err := ioutil.ReadFile(stateFile); err == nil && strings.TrimSpace(string(state)) == hash {
+		return false, nil
+	}
+	cmd := execCommand("npm", "install", "--prefix", dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("error installing packages for %s: %v; output from npm:\n-->\n%s<--",
+			s.Path, err, string(output))
+	}
+	if err := os.MkdirAll(filepath.Dir(stateFile), os.FileMode(0755)); err != nil {
+		return false, err
+	}
+	if err := ioutil.WriteFile(stateFile, []byte(hash+"\n"), os.FileMode(0644)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
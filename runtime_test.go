@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/juju/testing/checkers"
+)
+
+func TestScriptCommand(t *testing.T) {
+	scriptsPath := "/home/user/.ralph-cli/scripts"
+
+	var cases = map[string]struct {
+		script   Script
+		wantName string
+		wantArgs []string
+	}{
+		"#0 Script without manifest": {
+			script:   Script{Path: filepath.Join(scriptsPath, "script.sh")},
+			wantName: filepath.Join(scriptsPath, "script.sh"),
+			wantArgs: nil,
+		},
+		"#1 Python script": {
+			script: Script{
+				Path:     filepath.Join(scriptsPath, "idrac.py"),
+				Manifest: &Manifest{Language: "python", Args: []string{"--verbose"}},
+			},
+			wantName: filepath.Join(scriptsPath, "idrac_env", "bin", "python"),
+			wantArgs: []string{filepath.Join(scriptsPath, "idrac.py"), "--verbose"},
+		},
+		"#2 Go script": {
+			script: Script{
+				Path:     filepath.Join(scriptsPath, "collector.go"),
+				Manifest: &Manifest{Language: "go", Args: []string{"--host={{.Host}}"}},
+			},
+			wantName: "go",
+			wantArgs: []string{"run", filepath.Join(scriptsPath, "collector.go"), "--host=10.20.30.40"},
+		},
+		"#3 Node.js script with Interpreter": {
+			script: Script{
+				Path:     filepath.Join(scriptsPath, "collector.js"),
+				Manifest: &Manifest{Language: "node", Interpreter: "/opt/node/bin/node"},
+			},
+			wantName: "/opt/node/bin/node",
+			wantArgs: []string{filepath.Join(scriptsPath, "collector.js")},
+		},
+		"#4 Bash script with Interpreter": {
+			script: Script{
+				Path:     filepath.Join(scriptsPath, "collector.sh"),
+				Manifest: &Manifest{Language: "bash", Interpreter: "/bin/bash"},
+			},
+			wantName: "/bin/bash",
+			wantArgs: []string{filepath.Join(scriptsPath, "collector.sh")},
+		},
+		"#5 Command and Args templates": {
+			script: Script{
+				Path: filepath.Join(scriptsPath, "collector.sh"),
+				Manifest: &Manifest{
+					Language:    "bash",
+					Interpreter: "/bin/bash",
+					Command:     "{{.ScriptDir}}/wrapper",
+					Args:        []string{"{{.Interpreter}}", "-e", "{{.Script}}", "{{.WorkDir}}"},
+					WorkDir:     "collector",
+				},
+			},
+			wantName: filepath.Join(scriptsPath, "wrapper"),
+			wantArgs: []string{"/bin/bash", "-e", filepath.Join(scriptsPath, "collector.sh"), filepath.Join(scriptsPath, "collector")},
+		},
+	}
+
+	for tn, tc := range cases {
+		gotName, gotArgs, err := tc.script.command(Addr("10.20.30.40"))
+		if err != nil {
+			t.Fatalf("%s\nerr: %s", tn, err)
+		}
+		if gotName != tc.wantName || !TestEqStr(gotArgs, tc.wantArgs) {
+			t.Errorf("%s\n got: %s %q\nwant: %s %q", tn, gotName, gotArgs, tc.wantName, tc.wantArgs)
+		}
+	}
+}
+
+func TestScriptEnv(t *testing.T) {
+	scriptsPath := "/home/user/.ralph-cli/scripts"
+
+	var cases = map[string]struct {
+		script Script
+		want   []string
+	}{
+		"#0 Script without manifest": {
+			script: Script{Path: filepath.Join(scriptsPath, "script.sh")},
+			want:   nil,
+		},
+		"#1 Env templates": {
+			script: Script{
+				Path: filepath.Join(scriptsPath, "collector.go"),
+				Manifest: &Manifest{
+					Language: "go",
+					Env: map[string]string{
+						"GOPATH":          "{{.WorkDir}}",
+						"COLLECTOR_DEBUG": "1",
+					},
+					WorkDir: "/opt/collector",
+				},
+			},
+			want: []string{"COLLECTOR_DEBUG=1", "GOPATH=/opt/collector"},
+		},
+		"#2 NODE_PATH for Node.js scripts": {
+			script: Script{
+				Path:     filepath.Join(scriptsPath, "collector.js"),
+				Manifest: &Manifest{Language: "node", WorkDir: "collector"},
+			},
+			want: []string{fmt.Sprintf("NODE_PATH=%s", filepath.Join(scriptsPath, "collector", "node_modules"))},
+		},
+		"#3 NODE_PATH given explicitly": {
+			script: Script{
+				Path: filepath.Join(scriptsPath, "collector.js"),
+				Manifest: &Manifest{
+					Language: "node",
+					Env:      map[string]string{"NODE_PATH": "/usr/lib/node_modules"},
+				},
+			},
+			want: []string{"NODE_PATH=/usr/lib/node_modules"},
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := tc.script.env(Addr("10.20.30.40"))
+		if err != nil {
+			t.Fatalf("%s\nerr: %s", tn, err)
+		}
+		if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+			t.Errorf("%s\n%s", tn, err)
+		}
+	}
+}
+
+// Helper process for TestPrepareNodeModules - it records calls to npm in the
+// dir given to --prefix.
+func TestNpmHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	log := filepath.Join(args[len(args)-1], "npm.log")
+	f, err := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		os.Exit(1)
+	}
+	fmt.Fprintln(f, strings.Join(args[1:len(args)-2], " "))
+	f.Close()
+	os.Exit(0)
+}
+
+func TestPrepareNodeModules(t *testing.T) {
+	execCommand = GetHelperCommand("TestNpmHelperProcess")
+	defer func() { execCommand = exec.Command }()
+
+	cfgDir, baseDir, err := GetTempCfgDir()
+	defer os.RemoveAll(baseDir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	pkgDir := filepath.Join(cfgDir, "scripts", "collector")
+	if err := os.MkdirAll(pkgDir, os.FileMode(0755)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	script := Script{
+		Path:     filepath.Join(cfgDir, "scripts", "collector.js"),
+		Manifest: &Manifest{Language: "node", WorkDir: "collector"},
+	}
+	pkgFile := filepath.Join(pkgDir, "package.json")
+	npmLog := filepath.Join(pkgDir, "npm.log")
+
+	// Steps are executed in order.
+	var steps = []struct {
+		desc    string
+		pkg     string // contents of package.json ("" means no such file)
+		want    bool
+		wantLog string
+	}{
+		{"#0 No package.json", "", false, ""},
+		{"#1 Packages not installed yet", `{"dependencies": {"request": "2.79.0"}}`, true, "npm install\n"},
+		{"#2 Nothing changed", `{"dependencies": {"request": "2.79.0"}}`, false, "npm install\n"},
+		{"#3 package.json changed", `{"dependencies": {"request": "2.80.0"}}`, true, "npm install\nnpm install\n"},
+	}
+
+	for _, step := range steps {
+		if step.pkg != "" {
+			if err := ioutil.WriteFile(pkgFile, []byte(step.pkg), os.FileMode(0644)); err != nil {
+				t.Fatalf("%s\nerr: %s", step.desc, err)
+			}
+		}
+		got, err := PrepareNodeModules(script)
+		if err != nil {
+			t.Fatalf("%s\nerr: %s", step.desc, err)
+		}
+		if got != step.want {
+			t.Errorf("%s\n got: %v\nwant: %v", step.desc, got, step.want)
+		}
+		log, _ := ioutil.ReadFile(npmLog)
+		if string(log) != step.wantLog {
+			t.Errorf("%s\n got npm calls: %q\nwant npm calls: %q", step.desc, log, step.wantLog)
+		}
+	}
+}
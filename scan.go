@@ -1,12 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Script represents a single, user script which performs the actual scan of a single
@@ -16,10 +23,86 @@ import (
 type Script struct {
 	Path     string
 	Manifest *Manifest
+	Timeout  time.Duration // zero means no timeout
+	recorder *hostRecorder // when not nil, runs of the script are recorded (see ScanOpts.RecordDir)
 }
 
 var execCommand = exec.Command
 
+// ScriptParams holds parameters of a scan, which are passed to scan scripts
+// (see prepareEnv and ScriptContext), so they may skip collecting data that is
+// not needed.
+type ScriptParams struct {
+	Components []string    // requested components, e.g. "eth", "mem" (see componentNames)
+	WithModel  bool        // whether model name is requested
+	Ralph      *RalphState // state of the host stored in Ralph (nil if unknown)
+}
+
+// RalphState describes the state of the scanned host, as it is stored in Ralph.
+type RalphState struct {
+	BaseObjectID int    `json:"base_object_id"`
+	SerialNumber string `json:"serial_number"`
+}
+
+// ScriptContext holds everything a scan script needs to know about the scan
+// (including management credentials). It's written to a temporary file (see
+// writeScriptContext), readable only by its owner, whose path is passed to the
+// script as RALPH_CLI_CONTEXT, so credentials don't need to be put into the
+// environment of the script (and of everything it launches).
+type ScriptContext struct {
+	ContractVersion        int         `json:"contract_version"`
+	Host                   Addr        `json:"host"`
+	ManagementUserName     string      `json:"management_user_name"`
+	ManagementUserPassword string      `json:"management_user_password"`
+	Components             []string    `json:"components"`
+	WithModel              bool        `json:"with_model"`
+	Ralph                  *RalphState `json:"ralph"`
+}
+
+// newScriptContext returns ScriptContext for scanning addr with given config
+// (holding management credentials for this host) and params.
+func newScriptContext(addr Addr, cfg *Config, params ScriptParams) ScriptContext {
+	components := params.Components
+	if components == nil {
+		components = []string{}
+	}
+	return ScriptContext{
+		ContractVersion:        ContractVersion,
+		Host:                   addr,
+		ManagementUserName:     cfg.ManagementUserName,
+		ManagementUserPassword: cfg.ManagementUserPassword,
+		Components:             components,
+		WithModel:              params.WithModel,
+		Ralph:                  params.Ralph,
+	}
+}
+
+// writeScriptContext writes sc (in JSON) to a new temporary file with 0600
+// permissions and returns its path. It's up to the caller to remove this file.
+func writeScriptContext(sc ScriptContext) (string, error) {
+	data, err := json.MarshalIndent(sc, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling script context: %v", err)
+	}
+	f, err := ioutil.TempFile("", "ralph-cli-context-")
+	if err != nil {
+		return "", fmt.Errorf("error creating script context file: %v", err)
+	}
+	// ioutil.TempFile creates files with 0600 permissions already, but it's
+	// better not to rely on that when it comes to credentials.
+	if err := f.Chmod(os.FileMode(0600)); err == nil {
+		_, err = f.Write(data)
+	}
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("error writing script context file: %v", err)
+	}
+	return f.Name(), nil
+}
+
 // NewScript creates a new instance of Script given as sName. It also loads
 // manifest file for this script, if present.
 // Scripts should be located in "scripts" subdir of cfgDir. When cfgDir is given as an
@@ -34,9 +117,15 @@ func NewScript(sName, cfgDir string) (Script, error) {
 		return Script{}, err
 	}
 
+	var timeout time.Duration
+	if mf != nil {
+		timeout = time.Duration(mf.Timeout) * time.Second
+	}
+
 	return Script{
 		Path:     sPath,
 		Manifest: mf,
+		Timeout:  timeout,
 	}, nil
 }
 
@@ -50,78 +139,271 @@ func changeExt(fName, newExt string) string {
 }
 
 // Run launches a scan Script on a given address (at this moment, only IPs are fully
-// supported). If Script has a Manifest, and the Language in this Manifest is set to
-// "python", then the interpreter from a virtualenv associated with this script will
-// be used to launch it.
-func (s Script) Run(addrToScan Addr, cfg *Config) (*ScanResult, error) {
-	var cmd *exec.Cmd
-	var err error
-	var res ScanResult
+// supported). If Script has a Manifest, it decides how the script is launched (see
+// Script.command) - e.g. when the Language in this Manifest is set to "python", then
+// the interpreter from a virtualenv associated with this script will be used to
+// launch it. Extra environment variables and working dir are taken from Manifest too.
+// The script (along with all of its children) is killed when it runs longer than
+// its Timeout (if given), or when ctx is cancelled (e.g. on Ctrl-C). Params tell
+// the script what is requested from it - they're passed to it (along with the
+// management credentials) in a context file (see ScriptContext), which is removed
+// after the script finishes.
+func (s Script) Run(ctx context.Context, addrToScan Addr, cfg *Config, params ScriptParams) (*ScanResult, error) {
+	name, args, err := s.command(addrToScan)
+	if err != nil {
+		return nil, err
+	}
+	extraEnv, err := s.env(addrToScan)
+	if err != nil {
+		return nil, err
+	}
+	cmd := execCommand(name, args...)
+	cmd.Dir = s.workDir()
 
-	switch {
-	case s.Manifest != nil && s.Manifest.Language == "python":
-		python := filepath.Join(MakeVenvPath(s), "bin", "python")
-		cmd = execCommand(python, s.Path)
-	default:
-		cmd = execCommand(s.Path)
+	// Management credentials may differ between hosts (see Config.Credentials).
+	hostCfg, err := cfg.ForHost(addrToScan, filepath.Base(s.Path))
+	if err != nil {
+		return nil, err
 	}
 
+	sc := newScriptContext(addrToScan, hostCfg, params)
+	contextPath, err := writeScriptContext(sc)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(contextPath)
+
 	// This condition will be false only during some tests (see GetHelperCommand),
 	// and in such case, we need to preserve cmd.Env contents, hence this check
 	// (i.e., prepareEnv should only be launched when cmd.Env is empty).
 	if len(cmd.Env) == 0 {
-		cmd.Env = prepareEnv(os.Environ(), addrToScan, cfg)
+		cmd.Env = prepareEnv(os.Environ(), addrToScan, hostCfg, params, contextPath)
+	}
+	cmd.Env = append(cmd.Env, extraEnv...)
+
+	stdout, stderr, err := s.runCmd(ctx, cmd, addrToScan)
+	if s.recorder != nil {
+		if rErr := s.recorder.recordScript(cmd.Env, sc, hostCfg, stdout, stderr, err); rErr != nil {
+			return nil, rErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := parseScanResult(stdout, fmt.Sprintf("output of script %s", s.Path))
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling script output: %s\noutput from script:\n-->\n%s<--",
+			err, string(stdout))
+	}
+	res.Diagnostics = string(stderr)
+	return res, nil
+}
+
+// runCmd is a helper method for Script.Run, which runs cmd and returns its
+// stdout (i.e. the result) and stderr (i.e. diagnostics) separately. Stderr is
+// also streamed to the log as it goes, line by line, with a prefix telling
+// which script and host it concerns (see scriptLogPrefix). When cmd doesn't
+// finish before s.Timeout elapses or ctx is cancelled, its whole process group
+// is killed, and ScriptTimeoutError or ScriptInterruptedError is returned,
+// respectively. All the errors include the output (both stdout and stderr)
+// captured before they occurred, which is also returned along with them.
+func (s Script) runCmd(ctx context.Context, cmd *exec.Cmd, addrToScan Addr) (stdout, stderr []byte, err error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, &ScriptInterruptedError{Script: s.Path}
+	}
+	var outBuf, errBuf bytes.Buffer
+	var output syncBuffer // stdout and stderr interleaved, as they come
+	logWriter := &lineLogger{prefix: scriptLogPrefix(s.Path, addrToScan)}
+	cmd.Stdout = io.MultiWriter(&outBuf, &output)
+	cmd.Stderr = io.MultiWriter(&errBuf, &output, logWriter)
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("error running script %s: %s", s.Path, err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		logWriter.Flush()
+		if err != nil {
+			return outBuf.Bytes(), errBuf.Bytes(), fmt.Errorf("error running script %s: %s\noutput from script:\n-->\n%s<--",
+				s.Path, err, output.Bytes())
+		}
+		return outBuf.Bytes(), errBuf.Bytes(), nil
+	case <-ctx.Done():
+		// The error is irrelevant here - the script may have just finished.
+		killProcessGroup(cmd)
+		<-done
+		logWriter.Flush()
+		if ctx.Err() == context.DeadlineExceeded {
+			return outBuf.Bytes(), errBuf.Bytes(), &ScriptTimeoutError{Script: s.Path, Timeout: s.Timeout, Output: output.Bytes()}
+		}
+		return outBuf.Bytes(), errBuf.Bytes(), &ScriptInterruptedError{Script: s.Path, Output: output.Bytes()}
 	}
+}
+
+// scriptLogPrefix returns the prefix for log lines coming from a script given
+// as path, launched on host (e.g. "[script:idrac host:10.0.0.1]").
+func scriptLogPrefix(path string, host Addr) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return fmt.Sprintf("[script:%s host:%s]", name, host)
+}
+
+// syncBuffer is a bytes.Buffer which is safe for concurrent writes (stdout
+// and stderr of a command are copied by separate goroutines).
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write implements io.Writer interface for syncBuffer.
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
 
-	output, err := cmd.CombinedOutput()
+// Bytes returns the contents of syncBuffer.
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Bytes()
+}
+
+// lineLogger is an io.Writer which sends everything written to it to the log,
+// line by line, with a given prefix. Incomplete lines are buffered until they
+// are completed, or until Flush is called.
+type lineLogger struct {
+	prefix string
+	buf    []byte
+}
+
+// Write implements io.Writer interface for lineLogger.
+func (l *lineLogger) Write(p []byte) (int, error) {
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		l.logLine(l.buf[:i])
+		l.buf = l.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush logs the last, incomplete line (if any).
+func (l *lineLogger) Flush() {
+	if len(l.buf) > 0 {
+		l.logLine(l.buf)
+		l.buf = nil
+	}
+}
+
+// logLine is a helper method for lineLogger.
+func (l *lineLogger) logLine(line []byte) {
+	log.Printf("%s %s", l.prefix, bytes.TrimRight(line, "\r"))
+}
+
+// ReadScanResult reads a ready-made result of a scan (e.g. generated by some
+// other tool) from r. Such result should have exactly the same format as the
+// output of scan scripts (see Scripts Contract in docs), so it may be used in
+// their place (it's validated against the same schema - see
+// ValidateScanResult).
+func ReadScanResult(r io.Reader) (*ScanResult, error) {
+	data, err := ioutil.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("error running script %s: %s\noutput from script:\n-->\n%s<--",
-			s.Path, err, string(output))
+		return nil, fmt.Errorf("error reading scan result: %s", err)
 	}
-	err = json.Unmarshal(output, &res)
+	res, err := parseScanResult(data, "scan result")
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling script output: %s", err)
+		return nil, fmt.Errorf("error unmarshaling scan result: %s", err)
+	}
+	return res, nil
+}
+
+// parseScanResult is a helper function for Script.Run and ReadScanResult. It
+// validates data against the schema first (see ValidateScanResult), since
+// errors returned by json.Unmarshal don't tell much about the offending value,
+// and then decodes it into ScanResult. Source should tell where data came from
+// (e.g. "output of script idrac.py"). Results declaring a contract version
+// newer than ContractVersion are rejected.
+func parseScanResult(data []byte, source string) (*ScanResult, error) {
+	violations, err := ValidateScanResult(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := newSchemaError(source, violations); err != nil {
+		return nil, err
+	}
+	var res ScanResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	if res.ContractVersion > ContractVersion {
+		return nil, fmt.Errorf("%s conforms to contract version %d, but this version of ralph-cli supports versions up to %d",
+			source, res.ContractVersion, ContractVersion)
 	}
 	return &res, nil
 }
 
 // prepareEnv is a helper function for Script.Run. It modifies the environment that
-// should be used for executing given Script.
-func prepareEnv(oldEnv []string, addrToScan Addr, cfg *Config) (newEnv []string) {
+// should be used for executing given Script, i.e. it sets variables described in
+// Scripts Contract (see contractEnvVars): the host to scan, contract version,
+// requested components (comma-separated, empty if none) along with whether model
+// name is requested ("true" or "false"), and the path to the context file (see
+// ScriptContext). Management credentials are put there too, unless
+// cfg.CredentialsInEnv is set to false (see Config.credentialsInEnv) - in such
+// case, they're passed only in the context file.
+func prepareEnv(oldEnv []string, addrToScan Addr, cfg *Config, params ScriptParams, contextPath string) (newEnv []string) {
 	for _, e := range oldEnv {
 		pair := strings.Split(e, "=")
-		switch {
-		case pair[0] == "MANAGEMENT_USER_NAME":
-			continue
-		case pair[0] == "MANAGEMENT_USER_PASSWORD":
+		if isContractEnvVar(pair[0]) {
 			continue
-		case pair[0] == "IP_TO_SCAN":
-			continue
-		default:
-			newEnv = append(newEnv, e)
 		}
+		newEnv = append(newEnv, e)
+	}
+	if cfg.credentialsInEnv() {
+		newEnv = append(newEnv, fmt.Sprintf("MANAGEMENT_USER_NAME=%s", cfg.ManagementUserName))
+		newEnv = append(newEnv, fmt.Sprintf("MANAGEMENT_USER_PASSWORD=%s", cfg.ManagementUserPassword))
 	}
-	newEnv = append(newEnv, fmt.Sprintf("MANAGEMENT_USER_NAME=%s", cfg.ManagementUserName))
-	newEnv = append(newEnv, fmt.Sprintf("MANAGEMENT_USER_PASSWORD=%s", cfg.ManagementUserPassword))
 	newEnv = append(newEnv, fmt.Sprintf("IP_TO_SCAN=%s", addrToScan))
+	newEnv = append(newEnv, fmt.Sprintf("RALPH_CLI_CONTRACT_VERSION=%d", ContractVersion))
+	newEnv = append(newEnv, fmt.Sprintf("RALPH_CLI_COMPONENTS=%s", strings.Join(params.Components, ",")))
+	newEnv = append(newEnv, fmt.Sprintf("RALPH_CLI_WITH_MODEL=%t", params.WithModel))
+	newEnv = append(newEnv, fmt.Sprintf("RALPH_CLI_CONTEXT=%s", contextPath))
 	return newEnv
 }
 
 // ScanResult holds parsed output of a scan script.
 type ScanResult struct {
 	// TODO(xor-xor): Consider adding here a field holding an ADDR being scanned.
-	Ethernets         []Ethernet         `json:"ethernets"`
-	Memory            []Memory           `json:"memory"`
-	FibreChannelCards []FibreChannelCard `json:"fibre_channel_cards"`
-	Disks             []Disk             `json:"disks"`
-	Processors        []Processor        `json:"processors"`
-	SN                string             `json:"serial_number"`
-	FirmwareVersion   string             `json:"firmware_version"`
-	BIOSVersion       string             `json:"bios_version"`
-	ModelName         string             `json:"model_name"`
+	Ethernets          []Ethernet          `json:"ethernets"`
+	Memory             []Memory            `json:"memory"`
+	FibreChannelCards  []FibreChannelCard  `json:"fibre_channel_cards"`
+	Disks              []Disk              `json:"disks"`
+	Processors         []Processor         `json:"processors"`
+	PowerSupplies      []PowerSupply       `json:"power_supplies"`
+	StorageControllers []StorageController `json:"storage_controllers"`
+	GPUs               []GPU               `json:"gpus"`
+	NVMeDrives         []NVMeDrive         `json:"nvme_drives"`
+	SN                 string              `json:"serial_number"`
+	FirmwareVersion    string              `json:"firmware_version"`
+	BIOSVersion        string              `json:"bios_version"`
+	ModelName          string              `json:"model_name"`
+	ContractVersion    int                 `json:"contract_version,omitempty"` // zero if not declared by the script
+	Diagnostics        string              `json:"-"`                          // stderr of the script (see Script.Run)
 }
 
 func (sr ScanResult) String() string {
-	return fmt.Sprintf("Ethernets: %s\n\nMemory: %s\n\nFibreChannelCards: %s\n\nDisks: %s\n\nModelName: %s\n\nProcessors: %s\n\nFirmware Version: %s\n\nBIOS Version: %s\n\nSerial Number: %s\n",
-		sr.Ethernets, sr.Memory, sr.FibreChannelCards, sr.Disks, sr.ModelName, sr.Processors, sr.FirmwareVersion, sr.BIOSVersion, sr.SN)
+	return fmt.Sprintf("Ethernets: %s\n\nMemory: %s\n\nFibreChannelCards: %s\n\nDisks: %s\n\nPowerSupplies: %s\n\nStorageControllers: %s\n\nGPUs: %s\n\nNVMeDrives: %s\n\nModelName: %s\n\nProcessors: %s\n\nFirmware Version: %s\n\nBIOS Version: %s\n\nSerial Number: %s\n",
+		sr.Ethernets, sr.Memory, sr.FibreChannelCards, sr.Disks, sr.PowerSupplies, sr.StorageControllers, sr.GPUs, sr.NVMeDrives, sr.ModelName, sr.Processors, sr.FirmwareVersion, sr.BIOSVersion, sr.SN)
 }
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io/ioutil"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/juju/testing/checkers"
 )
@@ -31,6 +37,7 @@ func TestPrepareEnv(t *testing.T) {
 		oldEnv     []string
 		config     *Config
 		addrToScan Addr
+		params     ScriptParams
 		want       []string
 	}{
 		"#0 Existing Cmd.Env shouldn't be destroyed": {
@@ -41,9 +48,12 @@ func TestPrepareEnv(t *testing.T) {
 				RalphAPIKey:            "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
 				ManagementUserName:     "some_user",
 				ManagementUserPassword: "some_password",
+				CredentialsInEnv:       PtrToBool(true),
 			},
 			Addr("10.20.30.40"),
-			[]string{"GO_WANT_HELPER_PROCESS=1", "MANAGEMENT_USER_NAME=some_user", "MANAGEMENT_USER_PASSWORD=some_password", "IP_TO_SCAN=10.20.30.40"},
+			ScriptParams{},
+			[]string{"GO_WANT_HELPER_PROCESS=1", "MANAGEMENT_USER_NAME=some_user", "MANAGEMENT_USER_PASSWORD=some_password", "IP_TO_SCAN=10.20.30.40",
+				"RALPH_CLI_CONTRACT_VERSION=1", "RALPH_CLI_COMPONENTS=", "RALPH_CLI_WITH_MODEL=false", "RALPH_CLI_CONTEXT=/tmp/context.json"},
 		},
 		"#1 Existing management user/pass/IP should be overwritten": {
 			[]string{"MANAGEMENT_USER_NAME=old_user", "MANAGEMENT_USER_PASSWORD=old_password", "IP_TO_SCAN=11.22.33.44"},
@@ -53,19 +63,103 @@ func TestPrepareEnv(t *testing.T) {
 				RalphAPIKey:            "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
 				ManagementUserName:     "some_user",
 				ManagementUserPassword: "some_password",
+				CredentialsInEnv:       PtrToBool(true),
 			},
 			Addr("10.20.30.40"),
-			[]string{"MANAGEMENT_USER_NAME=some_user", "MANAGEMENT_USER_PASSWORD=some_password", "IP_TO_SCAN=10.20.30.40"},
+			ScriptParams{},
+			[]string{"MANAGEMENT_USER_NAME=some_user", "MANAGEMENT_USER_PASSWORD=some_password", "IP_TO_SCAN=10.20.30.40",
+				"RALPH_CLI_CONTRACT_VERSION=1", "RALPH_CLI_COMPONENTS=", "RALPH_CLI_WITH_MODEL=false", "RALPH_CLI_CONTEXT=/tmp/context.json"},
+		},
+		"#2 Requested components and model name should be passed": {
+			[]string{"RALPH_CLI_COMPONENTS=disk", "RALPH_CLI_CONTRACT_VERSION=99"},
+			&Config{
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+				CredentialsInEnv:       PtrToBool(true),
+			},
+			Addr("10.20.30.40"),
+			ScriptParams{Components: []string{"eth", "mem"}, WithModel: true},
+			[]string{"MANAGEMENT_USER_NAME=some_user", "MANAGEMENT_USER_PASSWORD=some_password", "IP_TO_SCAN=10.20.30.40",
+				"RALPH_CLI_CONTRACT_VERSION=1", "RALPH_CLI_COMPONENTS=eth,mem", "RALPH_CLI_WITH_MODEL=true", "RALPH_CLI_CONTEXT=/tmp/context.json"},
+		},
+		"#3 Credentials should be passed only in context file, when requested": {
+			[]string{"MANAGEMENT_USER_PASSWORD=old_password", "RALPH_CLI_CONTEXT=/tmp/old.json"},
+			&Config{
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+				CredentialsInEnv:       PtrToBool(false),
+			},
+			Addr("10.20.30.40"),
+			ScriptParams{},
+			[]string{"IP_TO_SCAN=10.20.30.40",
+				"RALPH_CLI_CONTRACT_VERSION=1", "RALPH_CLI_COMPONENTS=", "RALPH_CLI_WITH_MODEL=false", "RALPH_CLI_CONTEXT=/tmp/context.json"},
+		},
+		"#4 Credentials should be passed via environment by default (deprecated)": {
+			[]string{},
+			&Config{
+				ManagementUserName:     "some_user",
+				ManagementUserPassword: "some_password",
+			},
+			Addr("10.20.30.40"),
+			ScriptParams{},
+			[]string{"MANAGEMENT_USER_NAME=some_user", "MANAGEMENT_USER_PASSWORD=some_password", "IP_TO_SCAN=10.20.30.40",
+				"RALPH_CLI_CONTRACT_VERSION=1", "RALPH_CLI_COMPONENTS=", "RALPH_CLI_WITH_MODEL=false", "RALPH_CLI_CONTEXT=/tmp/context.json"},
 		},
 	}
 	for tn, tc := range cases {
-		got := prepareEnv(tc.oldEnv, tc.addrToScan, tc.config)
+		got := prepareEnv(tc.oldEnv, tc.addrToScan, tc.config, tc.params, "/tmp/context.json")
 		if !TestEqStr(got, tc.want) {
 			t.Errorf("%s\n got: %v\nwant: %v", tn, got, tc.want)
 		}
 	}
 }
 
+func TestWriteScriptContext(t *testing.T) {
+	config := &Config{
+		ManagementUserName:     "some_user",
+		ManagementUserPassword: "some_password",
+	}
+	params := ScriptParams{
+		Components: []string{"eth", "mem"},
+		Ralph:      &RalphState{BaseObjectID: 12, SerialNumber: "UUUZZZ1"},
+	}
+	want := `{
+    "contract_version": 1,
+    "host": "10.20.30.40",
+    "management_user_name": "some_user",
+    "management_user_password": "some_password",
+    "components": [
+        "eth",
+        "mem"
+    ],
+    "with_model": false,
+    "ralph": {
+        "base_object_id": 12,
+        "serial_number": "UUUZZZ1"
+    }
+}`
+
+	path, err := writeScriptContext(newScriptContext(Addr("10.20.30.40"), config, params))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(path)
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("context file has wrong permissions: %#o", perm)
+	}
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(got) != want {
+		t.Errorf("\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func TestRun(t *testing.T) {
 	execCommand = GetHelperCommand("TestRunHelperProcess")
 	defer func() { execCommand = exec.Command }()
@@ -128,7 +222,7 @@ func TestRun(t *testing.T) {
 		},
 	}
 	for tn, tc := range cases {
-		got, err := tc.script.Run(tc.addrToScan, tc.config)
+		got, err := tc.script.Run(context.Background(), tc.addrToScan, tc.config, ScriptParams{})
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
@@ -138,6 +232,106 @@ func TestRun(t *testing.T) {
 	}
 }
 
+// Helper process for TestRunWithDiagnostics - it prints warnings to stderr, and
+// the result to stdout.
+func TestNoisyHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "InsecureRequestWarning: Unverified HTTPS request is being made.")
+	fmt.Fprint(os.Stdout, `{"serial_number": "UUUZZZ1"}`)
+	fmt.Fprint(os.Stderr, "Done.")
+	os.Exit(0)
+}
+
+func TestRunWithDiagnostics(t *testing.T) {
+	execCommand = GetHelperCommand("TestNoisyHelperProcess")
+	defer func() { execCommand = exec.Command }()
+	var logged bytes.Buffer
+	flags := log.Flags()
+	log.SetOutput(&logged)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	}()
+
+	config := &Config{
+		ManagementUserName:     "some_user",
+		ManagementUserPassword: "some_password",
+	}
+	script := Script{Path: "/path/to/homedir/.ralph-cli/scripts/idrac.py"}
+	want := &ScanResult{
+		SN:          "UUUZZZ1",
+		Diagnostics: "InsecureRequestWarning: Unverified HTTPS request is being made.\nDone.",
+	}
+	wantLogged := "[script:idrac host:10.0.0.1] InsecureRequestWarning: Unverified HTTPS request is being made.\n" +
+		"[script:idrac host:10.0.0.1] Done.\n"
+
+	got, err := script.Run(context.Background(), Addr("10.0.0.1"), config, ScriptParams{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if eq, err := checkers.DeepEqual(got, want); !eq {
+		t.Errorf("%s", err)
+	}
+	if logged.String() != wantLogged {
+		t.Errorf("\n got logged: %q\nwant logged: %q", logged.String(), wantLogged)
+	}
+}
+
+// Helper process for TestRunTimeout - it prints something and then hangs.
+func TestHangingHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprintln(os.Stdout, "connecting...")
+	time.Sleep(time.Minute)
+	os.Exit(0)
+}
+
+func TestRunTimeout(t *testing.T) {
+	execCommand = GetHelperCommand("TestHangingHelperProcess")
+	defer func() { execCommand = exec.Command }()
+
+	config := &Config{
+		ManagementUserName:     "some_user",
+		ManagementUserPassword: "some_password",
+	}
+	script := Script{Path: "/path/to/homedir/.ralph-cli/scripts/hanging.py"}
+
+	// Timeout given in Script.
+	script.Timeout = 200 * time.Millisecond
+	start := time.Now()
+	_, err := script.Run(context.Background(), Addr("10.20.30.40"), config, ScriptParams{})
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("script hasn't been killed on timeout (it took %s)", elapsed)
+	}
+	switch e := err.(type) {
+	case *ScriptTimeoutError:
+		if e.Timeout != script.Timeout || !strings.Contains(string(e.Output), "connecting...") {
+			t.Errorf("got unexpected error: %q", e)
+		}
+	default:
+		t.Errorf("expected ScriptTimeoutError, got: %#v", err)
+	}
+
+	// Cancellation (e.g. on Ctrl-C).
+	script.Timeout = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+	_, err = script.Run(ctx, Addr("10.20.30.40"), config, ScriptParams{})
+	if _, ok := err.(*ScriptInterruptedError); !ok {
+		t.Errorf("expected ScriptInterruptedError, got: %#v", err)
+	}
+
+	// Cancelled context shouldn't allow running any more scripts.
+	_, err = script.Run(ctx, Addr("10.20.30.40"), config, ScriptParams{})
+	if _, ok := err.(*ScriptInterruptedError); !ok {
+		t.Errorf("expected ScriptInterruptedError, got: %#v", err)
+	}
+}
+
 func TestNewScript(t *testing.T) {
 	cfgDir, baseDir, err := GetTempCfgDir()
 	defer os.RemoveAll(baseDir)
@@ -167,3 +361,58 @@ func TestNewScript(t *testing.T) {
 }
 
 // TODO(xor-xor): Add test cases for missing script.
+
+func TestReadScanResult(t *testing.T) {
+	var cases = map[string]struct {
+		input  string
+		errMsg string
+		want   *ScanResult
+	}{
+		"#0 Valid result": {
+			`{"serial_number": "UUUZZZ1", "memory": [{"model_name": "Samsung DDR3 DIMM", "size": 16384, "speed": 1600}]}`,
+			"",
+			&ScanResult{
+				Memory: []Memory{
+					Memory{ModelName: "Samsung DDR3 DIMM", Size: 16384, Speed: 1600},
+				},
+				SN: "UUUZZZ1",
+			},
+		},
+		"#1 Invalid JSON": {
+			`{"serial_number": "UUUZZZ1",`,
+			"error unmarshaling scan result",
+			nil,
+		},
+		"#2 Result not conforming to schema": {
+			`{"serial_number": "UUUZZZ1", "memory": [{"model_name": "Samsung DDR3 DIMM", "size": "16GB", "speed": 1600}]}`,
+			`$.memory[0].size: expected integer or null, got "16GB"`,
+			nil,
+		},
+		"#3 Result with contract version declared": {
+			`{"contract_version": 1, "serial_number": "UUUZZZ1"}`,
+			"",
+			&ScanResult{SN: "UUUZZZ1", ContractVersion: 1},
+		},
+		"#4 Result with unsupported contract version": {
+			`{"contract_version": 2, "serial_number": "UUUZZZ1"}`,
+			"conforms to contract version 2, but this version of ralph-cli supports versions up to 1",
+			nil,
+		},
+	}
+	for tn, tc := range cases {
+		got, err := ReadScanResult(strings.NewReader(tc.input))
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if eq, err := checkers.DeepEqual(got, tc.want); !eq {
+				t.Errorf("%s\n%s", tn, err)
+			}
+		}
+	}
+}
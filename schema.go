@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContractVersion is the version of the scripts contract spoken by ralph-cli.
+// It's passed to scan scripts (see prepareEnv), and it's also the version of
+// the JSON Schema describing their output (see ScanResult). The schema itself
+// is bundled with the binary, along with scripts (see bundled_scripts dir).
+const ContractVersion = 1
+
+// scanResultSchemaAsset is the name of the asset holding the JSON Schema for
+// ScanResult (see bindata.go).
+var scanResultSchemaAsset = fmt.Sprintf("scan_result.v%d.schema.json", ContractVersion)
+
+// jsonSchema is a (pretty limited) subset of JSON Schema (draft 4), which is
+// sufficient for describing ScanResult. Supported keywords are: type,
+// properties, required, items, enum, pattern and minimum - all the others are
+// ignored.
+type jsonSchema struct {
+	Type       schemaTypes            `json:"type"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Required   []string               `json:"required"`
+	Items      *jsonSchema            `json:"items"`
+	Enum       []interface{}          `json:"enum"`
+	Pattern    string                 `json:"pattern"`
+	Minimum    *float64               `json:"minimum"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes holds JSON types allowed by jsonSchema (in JSON Schema, "type"
+// may be given as a string or as an array of strings).
+type schemaTypes []string
+
+// UnmarshalJSON deserializes schemaTypes from []byte.
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = schemaTypes{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(data, &ss); err != nil {
+		return fmt.Errorf("type should be a string or an array of strings, got: %s", data)
+	}
+	*t = ss
+	return nil
+}
+
+// String returns the list of allowed types in a human-readable form (e.g.
+// "integer or null").
+func (t schemaTypes) String() string {
+	return strings.Join(t, " or ")
+}
+
+// compile parses patterns given in the schema (and in its subschemas).
+func (s *jsonSchema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+var (
+	scanResultSchema     *jsonSchema
+	scanResultSchemaErr  error
+	scanResultSchemaOnce sync.Once
+)
+
+// getScanResultSchema loads the JSON Schema for ScanResult from the asset
+// bundled with the binary (it's done only once).
+func getScanResultSchema() (*jsonSchema, error) {
+	scanResultSchemaOnce.Do(func() {
+		data, err := Asset(scanResultSchemaAsset)
+		if err != nil {
+			scanResultSchemaErr = err
+			return
+		}
+		var s jsonSchema
+		if err := json.Unmarshal(data, &s); err != nil {
+			scanResultSchemaErr = fmt.Errorf("error loading schema %s: %v", scanResultSchemaAsset, err)
+			return
+		}
+		if err := s.compile(); err != nil {
+			scanResultSchemaErr = fmt.Errorf("error loading schema %s: %v", scanResultSchemaAsset, err)
+			return
+		}
+		scanResultSchema = &s
+	})
+	return scanResultSchema, scanResultSchemaErr
+}
+
+// ValidateScanResult checks if data (i.e. the output of a scan script)
+// conforms to the JSON Schema for ScanResult. Each violation of the schema is
+// returned as a separate message, telling the JSON path of the offending
+// value, the expected type (or format) and the value itself, e.g.:
+//
+//	$.memory[0].size: expected integer or null, got "16GB"
+//
+// An error is returned only when data is not a valid JSON (or when the schema
+// can't be loaded).
+func ValidateScanResult(data []byte) ([]string, error) {
+	schema, err := getScanResultSchema()
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	return schema.validate("$", v), nil
+}
+
+// newSchemaError returns violations of the schema (see ValidateScanResult)
+// as a single ValidationError, or nil if there are none. Source should tell
+// where the validated data came from (e.g. "output of script idrac.py").
+func newSchemaError(source string, violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	var errMsgs []*string
+	for i := range violations {
+		errMsgs = append(errMsgs, &violations[i])
+	}
+	return NewValidationError(source, errMsgs)
+}
+
+// validate checks v (decoded with json.Decoder.UseNumber) against s and returns
+// messages describing violations found. Path is the JSON path of v.
+func (s *jsonSchema) validate(path string, v interface{}) []string {
+	violation := func(expected string) []string {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, expected, formatJSONValue(v))}
+	}
+	if len(s.Type) > 0 && !s.Type.allows(v) {
+		return violation(s.Type.String())
+	}
+	if len(s.Enum) > 0 && !inEnum(v, s.Enum) {
+		return violation("one of " + formatEnum(s.Enum))
+	}
+	var msgs []string
+	switch val := v.(type) {
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(val) {
+			return violation(fmt.Sprintf("string matching %q", s.Pattern))
+		}
+	case json.Number:
+		if f, err := val.Float64(); err == nil && s.Minimum != nil && f < *s.Minimum {
+			return violation(fmt.Sprintf("number >= %s", strconv.FormatFloat(*s.Minimum, 'f', -1, 64)))
+		}
+	case map[string]interface{}:
+		for _, r := range s.Required {
+			if _, ok := val[r]; !ok {
+				msgs = append(msgs, fmt.Sprintf("%s: missing required property %q", path, r))
+			}
+		}
+		// Map iteration order is random.
+		var keys []string
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p, ok := s.Properties[k]; ok {
+				msgs = append(msgs, p.validate(path+"."+k, val[k])...)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range val {
+				msgs = append(msgs, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+	}
+	return msgs
+}
+
+// allows returns true if v is of one of the types from t.
+func (t schemaTypes) allows(v interface{}) bool {
+	for _, typ := range t {
+		switch val := v.(type) {
+		case nil:
+			if typ == "null" {
+				return true
+			}
+		case bool:
+			if typ == "boolean" {
+				return true
+			}
+		case string:
+			if typ == "string" {
+				return true
+			}
+		case json.Number:
+			if typ == "number" {
+				return true
+			}
+			if _, err := strconv.ParseInt(val.String(), 10, 64); err == nil && typ == "integer" {
+				return true
+			}
+		case map[string]interface{}:
+			if typ == "object" {
+				return true
+			}
+		case []interface{}:
+			if typ == "array" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inEnum returns true if v equals one of the values from enum.
+func inEnum(v interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		switch val := v.(type) {
+		case json.Number:
+			if f, ok := e.(float64); ok && val.String() == strconv.FormatFloat(f, 'f', -1, 64) {
+				return true
+			}
+		default:
+			if v == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatEnum returns values from enum in a human-readable form.
+func formatEnum(enum []interface{}) string {
+	var vals []string
+	for _, e := range enum {
+		vals = append(vals, formatJSONValue(e))
+	}
+	return strings.Join(vals, ", ")
+}
+
+// formatJSONValue returns v in JSON format, for use in error messages (objects
+// and arrays are not shown in full).
+func formatJSONValue(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestValidateScanResult(t *testing.T) {
+	var cases = map[string]struct {
+		input  string
+		want   []string
+		errMsg string
+	}{
+		"#0 Valid result": {
+			input: `{"serial_number": "UUUZZZ1", "memory": [{"model_name": "Samsung DDR3 DIMM", "size": 16384, "speed": null}]}`,
+		},
+		"#1 Wrong type": {
+			input: `{"memory": [{"size": 16384}, {"size": "16GB"}]}`,
+			want:  []string{`$.memory[1].size: expected integer or null, got "16GB"`},
+		},
+		"#2 Invalid MAC address": {
+			input: `{"ethernets": [{"mac": "aa:bb:cc:dd:ee:fx", "speed": "1 Gbps"}]}`,
+			want:  []string{`$.ethernets[0].mac: expected string matching "^(([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2})?$", got "aa:bb:cc:dd:ee:fx"`},
+		},
+		"#3 Many violations, sorted by path": {
+			input: `{"serial_number": 123, "disks": [{"size": -1, "slot": 1.5}], "fibre_channel_cards": [{"speed": "64 Gbit"}]}`,
+			want: []string{
+				`$.disks[0].size: expected number >= 0, got -1`,
+				`$.disks[0].slot: expected integer or null, got 1.5`,
+				`$.fibre_channel_cards[0].speed: expected one of "1 Gbit", "2 Gbit", "4 Gbit", "8 Gbit", "16 Gbit", "32 Gbit", "unknown speed", null, got "64 Gbit"`,
+				`$.serial_number: expected string or null, got 123`,
+			},
+		},
+		"#4 Not an object": {
+			input: `[]`,
+			want:  []string{`$: expected object, got array`},
+		},
+		"#5 Invalid JSON": {
+			input:  `{"serial_number": "UUUZZZ1",`,
+			errMsg: "invalid JSON",
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := ValidateScanResult([]byte(tc.input))
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if !TestEqStr(got, tc.want) {
+				t.Errorf("%s\n got: %q\nwant: %q", tn, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestValidateScanResultFixture(t *testing.T) {
+	data, err := ioutil.ReadFile("scan_test_fixtures/script_output.json")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	got, err := ValidateScanResult(data)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got unexpected violations: %q", got)
+	}
+}
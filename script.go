@@ -0,0 +1,500 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// removedBundledFilesList is the name of a file (in the scripts dir) listing
+// bundled files removed by the user (see RemoveScript), so PrepareCfgDir won't
+// restore them behind user's back.
+const removedBundledFilesList = ".removed_bundled_files"
+
+// ScriptInfo describes a scan script - either the one present in the scripts
+// dir, or a bundled one, which is not installed there (see RestoreScript).
+type ScriptInfo struct {
+	Name        string
+	Path        string
+	Manifest    *Manifest // nil when there's no manifest for this script
+	ManifestErr error     // error encountered while loading manifest (if any)
+	Bundled     bool
+	Installed   bool
+}
+
+// Venv returns the path to the virtualenv of the script and true if it
+// exists, or an empty string and false when the script doesn't need one (i.e.
+// it's not written in Python).
+func (si ScriptInfo) Venv() (path string, exists bool) {
+	if si.Manifest == nil || si.Manifest.Language != "python" {
+		return "", false
+	}
+	s := Script{Path: si.Path, Manifest: si.Manifest}
+	return MakeVenvPath(s), VenvExists(s)
+}
+
+// venvStatus is a helper method returning a short description of the
+// virtualenv of the script (for WriteScriptList).
+func (si ScriptInfo) venvStatus() string {
+	path, exists := si.Venv()
+	switch {
+	case path == "":
+		return "-"
+	case exists && VenvOutdated(Script{Path: si.Path, Manifest: si.Manifest}):
+		return "outdated"
+	case exists:
+		return "ready"
+	default:
+		return "missing"
+	}
+}
+
+// source is a helper method telling where the script comes from.
+func (si ScriptInfo) source() string {
+	switch {
+	case si.Bundled && !si.Installed:
+		return "bundled (not installed)"
+	case si.Bundled:
+		return "bundled"
+	default:
+		return "user"
+	}
+}
+
+// scriptsDir returns the path to the dir holding scan scripts.
+func scriptsDir(cfgDir string) string {
+	return filepath.Join(cfgDir, "scripts")
+}
+
+// checkScriptName returns an error when name can't be used as a name of a
+// script (i.e. it's empty or it points outside of the scripts dir).
+func checkScriptName(name string) error {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid script name: %q", name)
+	}
+	return nil
+}
+
+// bundledScripts returns names of bundled scripts (i.e. bundledFiles without
+// manifests).
+func bundledScripts() []string {
+	var names []string
+	for _, f := range bundledFiles {
+		if filepath.Ext(f) != ".toml" {
+			names = append(names, f)
+		}
+	}
+	return names
+}
+
+// isBundled returns true if file is one of bundledFiles.
+func isBundled(file string) bool {
+	for _, f := range bundledFiles {
+		if f == file {
+			return true
+		}
+	}
+	return false
+}
+
+// GetScriptInfo returns ScriptInfo for a script given as name. It's an error
+// if such script is neither present in the scripts dir, nor bundled. Errors
+// related to script's manifest are not returned, but stored in ManifestErr.
+func GetScriptInfo(name, cfgDir string) (*ScriptInfo, error) {
+	if err := checkScriptName(name); err != nil {
+		return nil, err
+	}
+	si := &ScriptInfo{
+		Name:    name,
+		Path:    filepath.Join(scriptsDir(cfgDir), name),
+		Bundled: isBundled(name),
+	}
+	si.Installed = fileExists(si.Path)
+	if !si.Installed && !si.Bundled {
+		return nil, fmt.Errorf("unknown script: %s", name)
+	}
+	si.Manifest, si.ManifestErr = GetManifest(changeExt(si.Path, "toml"))
+	return si, nil
+}
+
+// ListScripts returns ScriptInfo for all the scripts from the scripts dir, and
+// for bundled scripts which are not installed there, sorted by their names.
+// Manifests, virtualenvs (dirs) and hidden files are skipped.
+func ListScripts(cfgDir string) ([]*ScriptInfo, error) {
+	files, err := ioutil.ReadDir(scriptsDir(cfgDir))
+	if err != nil {
+		return nil, fmt.Errorf("error listing scripts: %v", err)
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || filepath.Ext(name) == ".toml" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		names = append(names, name)
+		seen[name] = true
+	}
+	for _, name := range bundledScripts() {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var scripts []*ScriptInfo
+	for _, name := range names {
+		si, err := GetScriptInfo(name, cfgDir)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, si)
+	}
+	return scripts, nil
+}
+
+// WriteScriptList writes scripts to w as a table.
+func WriteScriptList(w io.Writer, scripts []*ScriptInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tLANGUAGE\tVERSION\tVENV\tSOURCE")
+	for _, si := range scripts {
+		lang, version := "-", "-"
+		switch {
+		case si.ManifestErr != nil:
+			lang = "(invalid manifest)"
+		case si.Manifest != nil:
+			lang = si.Manifest.Language
+			if si.Manifest.LanguageVersion != 0 {
+				version = fmt.Sprint(si.Manifest.LanguageVersion)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", si.Name, lang, version, si.venvStatus(), si.source())
+	}
+	return tw.Flush()
+}
+
+// WriteScriptDetails writes to w everything that is known about the script
+// described by si (including its manifest and virtualenv).
+func WriteScriptDetails(w io.Writer, si *ScriptInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", si.Name)
+	fmt.Fprintf(tw, "Path:\t%s\n", si.Path)
+	fmt.Fprintf(tw, "Source:\t%s\n", si.source())
+	switch {
+	case si.ManifestErr != nil:
+		fmt.Fprintf(tw, "Manifest:\t%v\n", si.ManifestErr)
+	case si.Manifest == nil:
+		fmt.Fprintf(tw, "Manifest:\t(none)\n")
+	default:
+		fmt.Fprintf(tw, "Manifest:\t%s\n", si.Manifest.Path)
+		fmt.Fprintf(tw, "Language:\t%s\n", si.Manifest.Language)
+		if si.Manifest.LanguageVersion != 0 {
+			fmt.Fprintf(tw, "Language version:\t%d\n", si.Manifest.LanguageVersion)
+		}
+		for i, r := range si.Manifest.Requirements {
+			var label string
+			if i == 0 {
+				label = "Requirements:"
+			}
+			req := r.Name
+			if r.Version != "" {
+				req = fmt.Sprintf("%s==%s", r.Name, r.Version)
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", label, req)
+		}
+	}
+	if path, exists := si.Venv(); path != "" {
+		status := "not created yet"
+		if exists {
+			status = si.venvStatus()
+		}
+		fmt.Fprintf(tw, "Virtualenv:\t%s (%s)\n", path, status)
+	}
+	return tw.Flush()
+}
+
+// scriptTemplate holds files created by CreateScript for a given language.
+type scriptTemplate struct {
+	ext      string
+	source   string
+	manifest string
+}
+
+// ScriptTemplates holds templates used by CreateScript, keyed by language.
+// Scripts created from them follow Scripts Contract (see docs), returning no
+// components - discovering them is left to the user.
+var ScriptTemplates = map[string]scriptTemplate{
+	"python": {
+		ext: "py",
+		source: `#!/usr/bin/env python
+
+import json
+import os
+import sys
+
+
+def scan(host, user, password, components):
+    # Discover components of the host here (see "Scripts Contract" section
+    # in ralph-cli docs for the description of the expected output), skipping
+    # the ones which are not requested (e.g. 'eth', 'mem').
+    device_info = {
+        'contract_version': 1,
+        'serial_number': '',
+        'model_name': '',
+        'firmware_version': '',
+        'bios_version': '',
+        'processors': [],
+        'memory': [],
+        'ethernets': [],
+        'fibre_channel_cards': [],
+        'disks': [],
+        'power_supplies': [],
+        'storage_controllers': [],
+        'gpus': [],
+        'nvme_drives': [],
+    }
+    print(json.dumps(device_info))
+
+
+if __name__ == '__main__':
+    host = os.environ.get('IP_TO_SCAN', "")
+    # Management credentials (and other details of the scan) are passed in
+    # a context file.
+    with open(os.environ['RALPH_CLI_CONTEXT']) as f:
+        context = json.load(f)
+    user = context['management_user_name']
+    password = context['management_user_password']
+    components = context['components']
+    if host == "":
+        print("No IP address to scan has been provided.", file=sys.stderr)
+        sys.exit(1)
+    scan(host, user, password, components)
+`,
+		manifest: `Language = "python"
+LanguageVersion = 3
+
+# Requirements are installed into script's virtualenv, e.g.:
+# [[requirement]]
+# name = "requests"
+# version = "2.10.0"
+`,
+	},
+	"shell": {
+		ext: "sh",
+		source: `#!/bin/sh
+
+# Discover components of the host given as IP_TO_SCAN here (see "Scripts
+# Contract" section in ralph-cli docs for the description of the expected
+# output). Management credentials can be found in a JSON file given as
+# RALPH_CLI_CONTEXT (MANAGEMENT_USER_NAME and MANAGEMENT_USER_PASSWORD are
+# deprecated, see CredentialsInEnv in ralph-cli config). Components which are
+# not listed in RALPH_CLI_COMPONENTS may be skipped.
+
+if [ -z "$IP_TO_SCAN" ]; then
+    echo "No IP address to scan has been provided." >&2
+    exit 1
+fi
+
+cat <<EOF
+{
+    "contract_version": 1,
+    "serial_number": "",
+    "model_name": "",
+    "firmware_version": "",
+    "bios_version": "",
+    "processors": [],
+    "memory": [],
+    "ethernets": [],
+    "fibre_channel_cards": [],
+    "disks": [],
+    "power_supplies": [],
+    "storage_controllers": [],
+    "gpus": [],
+    "nvme_drives": []
+}
+EOF
+`,
+		manifest: `Language = "shell"
+`,
+	},
+}
+
+// ScriptLanguages returns languages supported by CreateScript, sorted.
+func ScriptLanguages() []string {
+	var langs []string
+	for lang := range ScriptTemplates {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// CreateScript creates a skeleton of a new scan script, along with its
+// manifest, from a template for a given language (see ScriptTemplates). When
+// name has no extension, the one appropriate for the language is appended.
+// Existing files are never overwritten.
+func CreateScript(name, lang, cfgDir string) (*ScriptInfo, error) {
+	tmpl, ok := ScriptTemplates[strings.ToLower(lang)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s (supported ones: %s)",
+			lang, strings.Join(ScriptLanguages(), ", "))
+	}
+	if filepath.Ext(name) == "" {
+		name = fmt.Sprintf("%s.%s", name, tmpl.ext)
+	}
+	if err := checkScriptName(name); err != nil {
+		return nil, err
+	}
+	if filepath.Ext(name) == ".toml" {
+		return nil, fmt.Errorf("invalid script name: %q (this extension is reserved for manifests)", name)
+	}
+	sPath := filepath.Join(scriptsDir(cfgDir), name)
+	mfPath := changeExt(sPath, "toml")
+	for _, path := range []string{sPath, mfPath} {
+		if fileExists(path) {
+			return nil, fmt.Errorf("file %s already exists", path)
+		}
+	}
+	if err := ioutil.WriteFile(sPath, []byte(tmpl.source), os.FileMode(0755)); err != nil {
+		return nil, fmt.Errorf("error creating script: %v", err)
+	}
+	if err := ioutil.WriteFile(mfPath, []byte(tmpl.manifest), os.FileMode(0644)); err != nil {
+		return nil, fmt.Errorf("error creating manifest: %v", err)
+	}
+	return GetScriptInfo(name, cfgDir)
+}
+
+// RestoreScript extracts a bundled script given as name, along with its
+// manifest, to the scripts dir, and returns paths of the restored files. When
+// any of these files already exists, it's an error - unless overwrite is true.
+func RestoreScript(name, cfgDir string, overwrite bool) ([]string, error) {
+	if err := checkScriptName(name); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range bundledFiles {
+		if changeExt(f, "toml") == changeExt(name, "toml") {
+			files = append(files, f)
+		}
+	}
+	if !isBundled(name) || len(files) == 0 {
+		return nil, fmt.Errorf("%s is not a bundled script (bundled ones: %s)",
+			name, strings.Join(bundledScripts(), ", "))
+	}
+	dir := scriptsDir(cfgDir)
+	var paths []string
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+		if fileExists(path) && !overwrite {
+			return nil, fmt.Errorf("file %s already exists (use '--force' switch to overwrite it)", path)
+		}
+		paths = append(paths, path)
+	}
+	for _, f := range files {
+		if err := RestoreAsset(dir, f); err != nil {
+			return nil, fmt.Errorf("error restoring %s: %v", f, err)
+		}
+	}
+	removed, err := readRemovedBundledFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		delete(removed, f)
+	}
+	if err := writeRemovedBundledFiles(dir, removed); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// RemoveScript removes a script given as name from the scripts dir, along
+// with its manifest and virtualenv, and returns paths of the removed files.
+// Bundled scripts removed this way are not restored by PrepareCfgDir (use
+// RestoreScript for that).
+func RemoveScript(name, cfgDir string) ([]string, error) {
+	si, err := GetScriptInfo(name, cfgDir)
+	if err != nil {
+		return nil, err
+	}
+	if !si.Installed {
+		return nil, fmt.Errorf("script %s is not installed", name)
+	}
+	venvPath, _ := si.Venv()
+	if venvPath == "" {
+		// Manifest may be missing or invalid, but virtualenv may still be
+		// there (e.g. left from a previous version of the script).
+		venvPath = MakeVenvPath(Script{Path: si.Path})
+	}
+	var removedPaths []string
+	for _, path := range []string{si.Path, changeExt(si.Path, "toml"), venvPath} {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return removedPaths, fmt.Errorf("error removing %s: %v", path, err)
+		}
+		removedPaths = append(removedPaths, path)
+	}
+	if si.Bundled {
+		dir := scriptsDir(cfgDir)
+		removed, err := readRemovedBundledFiles(dir)
+		if err != nil {
+			return removedPaths, err
+		}
+		for _, f := range bundledFiles {
+			if changeExt(f, "toml") == changeExt(name, "toml") {
+				removed[f] = true
+			}
+		}
+		if err := writeRemovedBundledFiles(dir, removed); err != nil {
+			return removedPaths, err
+		}
+	}
+	return removedPaths, nil
+}
+
+// readRemovedBundledFiles reads the list of bundled files removed by the user
+// (see removedBundledFilesList) from scripts dir given as dir.
+func readRemovedBundledFiles(dir string) (map[string]bool, error) {
+	removed := make(map[string]bool)
+	f, err := os.Open(filepath.Join(dir, removedBundledFilesList))
+	switch {
+	case os.IsNotExist(err):
+		return removed, nil
+	case err != nil:
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			removed[line] = true
+		}
+	}
+	return removed, scanner.Err()
+}
+
+// writeRemovedBundledFiles is a counterpart of readRemovedBundledFiles. When
+// there are no removed files, the list is deleted altogether.
+func writeRemovedBundledFiles(dir string, removed map[string]bool) error {
+	path := filepath.Join(dir, removedBundledFilesList)
+	if len(removed) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	var files []string
+	for f := range removed {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	data := []byte(strings.Join(files, "\n") + "\n")
+	return ioutil.WriteFile(path, data, os.FileMode(0644))
+}
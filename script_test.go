@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListScripts(t *testing.T) {
+	cfgDir, baseDir, err := GetTempCfgDir()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(baseDir)
+	dir := scriptsDir(cfgDir)
+	if err := os.Remove(filepath.Join(dir, "ilo.py")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "idrac_env"), os.FileMode(0755)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "custom.sh"), nil, os.FileMode(0755)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "custom.toml"), []byte("LanguageVersion = 1"), os.FileMode(0644)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	want := `NAME       LANGUAGE            VERSION  VENV     SOURCE
+custom.sh  (invalid manifest)  -        -        user
+idrac.py   python              3        missing  bundled
+ilo.py     python              3        missing  bundled (not installed)
+`
+
+	scripts, err := ListScripts(cfgDir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var got bytes.Buffer
+	if err := WriteScriptList(&got, scripts); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got.String() != want {
+		t.Errorf("\n got:\n%s\nwant:\n%s", got.String(), want)
+	}
+}
+
+func TestWriteScriptDetails(t *testing.T) {
+	cfgDir, baseDir, err := GetTempCfgDir()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(baseDir)
+	path := filepath.Join(scriptsDir(cfgDir), "idrac.py")
+	want := strings.Join([]string{
+		"Name:             idrac.py",
+		"Path:             " + path,
+		"Source:           bundled",
+		"Manifest:         " + changeExt(path, "toml"),
+		"Language:         python",
+		"Language version: 3",
+		"Requirements:     requests==2.10.0",
+		"Virtualenv:       " + filepath.Join(scriptsDir(cfgDir), "idrac_env") + " (not created yet)",
+		"",
+	}, "\n")
+
+	si, err := GetScriptInfo("idrac.py", cfgDir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var got bytes.Buffer
+	if err := WriteScriptDetails(&got, si); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got.String() != want {
+		t.Errorf("\n got:\n%s\nwant:\n%s", got.String(), want)
+	}
+}
+
+func TestCreateScript(t *testing.T) {
+	cfgDir, baseDir, err := GetTempCfgDir()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	var cases = map[string]struct {
+		name     string
+		lang     string
+		wantName string
+		errMsg   string
+	}{
+		"#0 Python script, extension appended": {
+			name:     "custom_python",
+			lang:     "python",
+			wantName: "custom_python.py",
+		},
+		"#1 Shell script with extension": {
+			name:     "custom_shell.sh",
+			lang:     "Shell",
+			wantName: "custom_shell.sh",
+		},
+		"#2 Unsupported language": {
+			name:   "custom",
+			lang:   "cobol",
+			errMsg: "unsupported language: cobol (supported ones: python, shell)",
+		},
+		"#3 Existing script": {
+			name:   "idrac.py",
+			lang:   "python",
+			errMsg: "already exists",
+		},
+		"#4 Script outside of scripts dir": {
+			name:   "../custom.py",
+			lang:   "python",
+			errMsg: "invalid script name",
+		},
+	}
+
+	for tn, tc := range cases {
+		si, err := CreateScript(tc.name, tc.lang, cfgDir)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if si.Name != tc.wantName || !si.Installed || si.Manifest == nil || si.ManifestErr != nil {
+				t.Errorf("%s\ngot unexpected script info: %+v", tn, si)
+			}
+			fi, err := os.Stat(si.Path)
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if fi.Mode().Perm()&0100 == 0 {
+				t.Errorf("%s\nscript %s is not executable", tn, si.Path)
+			}
+		}
+	}
+}
+
+func TestRemoveAndRestoreScript(t *testing.T) {
+	cfgDir, baseDir, err := GetTempCfgDir()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(baseDir)
+	dir := scriptsDir(cfgDir)
+	venvPath := filepath.Join(dir, "idrac_env")
+	if err := os.MkdirAll(filepath.Join(venvPath, "bin"), os.FileMode(0755)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Script, manifest and virtualenv should be removed together.
+	removed, err := RemoveScript("idrac.py", cfgDir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	want := []string{filepath.Join(dir, "idrac.py"), filepath.Join(dir, "idrac.toml"), venvPath}
+	if !TestEqStr(removed, want) {
+		t.Errorf("\n got: %v\nwant: %v", removed, want)
+	}
+	if _, err := RemoveScript("idrac.py", cfgDir); err == nil {
+		t.Errorf("removing script which is not installed should fail")
+	}
+
+	// Removed bundled scripts shouldn't be restored behind user's back...
+	if err := PrepareCfgDir(cfgDir, "config.toml"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if fileExists(filepath.Join(dir, "idrac.py")) {
+		t.Errorf("removed script has been restored by PrepareCfgDir")
+	}
+
+	// ...but only on demand.
+	restored, err := RestoreScript("idrac.py", cfgDir, false)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	want = []string{filepath.Join(dir, "idrac.py"), filepath.Join(dir, "idrac.toml")}
+	if !TestEqStr(restored, want) {
+		t.Errorf("\n got: %v\nwant: %v", restored, want)
+	}
+	if fileExists(filepath.Join(dir, removedBundledFilesList)) {
+		t.Errorf("list of removed bundled files should be deleted when empty")
+	}
+
+	// Existing files are overwritten only when requested.
+	if err := ioutil.WriteFile(filepath.Join(dir, "idrac.py"), []byte("modified"), os.FileMode(0755)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, err = RestoreScript("idrac.py", cfgDir, false)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("didn't get expected error for existing file, got: %q", err)
+	}
+	if _, err := RestoreScript("idrac.py", cfgDir, true); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "idrac.py"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(data) == "modified" {
+		t.Errorf("script hasn't been overwritten")
+	}
+
+	_, err = RestoreScript("custom.py", cfgDir, false)
+	if err == nil || !strings.Contains(err.Error(), "not a bundled script") {
+		t.Errorf("didn't get expected error for non-bundled script, got: %q", err)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ResolveSecret returns the secret pointed by ref, which may have one of the
+// following forms:
+//   - "env:VAR" - value of VAR environment variable;
+//   - "file:/path/to/file" - contents of a given file;
+//   - "cmd:some command" - output of a given shell command (e.g. "cmd:pass show
+//     ralph/idrac");
+//   - "secret-service:attribute value [attribute value...]" - secret stored in
+//     Secret Service (e.g. GNOME Keyring, KWallet), looked up by secret-tool;
+//   - "keyring:description" - key of type "user" stored in Linux kernel's
+//     keyring, looked up by keyctl in user's keyring;
+//   - "plain:value" - value given as-is (this is for values which happen to
+//     start with one of the above prefixes).
+//
+// Any other ref is returned as-is. Trailing newlines are stripped from the
+// secrets read from files and commands.
+func ResolveSecret(ref string) (string, error) {
+	var secret []byte
+	var err error
+	switch {
+	case strings.HasPrefix(ref, "plain:"):
+		return strings.TrimPrefix(ref, "plain:"), nil
+	case strings.HasPrefix(ref, "env:"):
+		name := strings.TrimPrefix(ref, "env:")
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return val, nil
+	case strings.HasPrefix(ref, "file:"):
+		path := strings.TrimPrefix(ref, "file:")
+		if secret, err = ioutil.ReadFile(path); err != nil {
+			return "", fmt.Errorf("error reading secret from file: %v", err)
+		}
+	case strings.HasPrefix(ref, "cmd:"):
+		secret, err = runSecretCommand("sh", "-c", strings.TrimPrefix(ref, "cmd:"))
+	case strings.HasPrefix(ref, "secret-service:"):
+		attrs := strings.Fields(strings.TrimPrefix(ref, "secret-service:"))
+		if len(attrs) == 0 || len(attrs)%2 != 0 {
+			return "", fmt.Errorf("secret-service reference should consist of attribute-value pairs: %q", ref)
+		}
+		secret, err = runSecretCommand("secret-tool", append([]string{"lookup"}, attrs...)...)
+	case strings.HasPrefix(ref, "keyring:"):
+		var id []byte
+		desc := strings.TrimPrefix(ref, "keyring:")
+		if id, err = runSecretCommand("keyctl", "search", "@u", "user", desc); err == nil {
+			secret, err = runSecretCommand("keyctl", "pipe", string(bytes.TrimSpace(id)))
+		}
+	default:
+		return ref, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(secret), "\r\n"), nil
+}
+
+// secretCache holds secrets resolved by resolveSecretOnce (along with the
+// errors, so failing commands etc. are not retried either).
+var secretCache = struct {
+	sync.Mutex
+	secrets map[string]cachedSecret
+}{secrets: make(map[string]cachedSecret)}
+
+type cachedSecret struct {
+	secret string
+	err    error
+}
+
+// resolveSecretOnce works like ResolveSecret, but each ref is resolved only
+// once, no matter how many hosts (and workers) need it - otherwise a scan of
+// a whole network would run a command or prompt for a password for every
+// single host. Concurrent calls wait for the pending one to finish.
+func resolveSecretOnce(ref string) (string, error) {
+	secretCache.Lock()
+	defer secretCache.Unlock()
+	if c, ok := secretCache.secrets[ref]; ok {
+		return c.secret, c.err
+	}
+	secret, err := ResolveSecret(ref)
+	secretCache.secrets[ref] = cachedSecret{secret, err}
+	return secret, err
+}
+
+// runSecretCommand is a helper function for ResolveSecret, which runs a given
+// command and returns its output. Stdout is never included in the returned
+// errors (so secrets won't leak into them), only stderr.
+func runSecretCommand(name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := execCommand(name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("error running %s: %v (%s)", name, err, msg)
+		}
+		return nil, fmt.Errorf("error running %s: %v", name, err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Helper process for TestResolveSecret - it prints the command that it was
+// called with (instead of the actual secret), so it can be checked by tests.
+func TestSecretHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	args = args[1:]
+	switch {
+	case args[0] == "keyctl" && args[1] == "search":
+		fmt.Fprintln(os.Stdout, "123456")
+	case args[0] == "secret-tool" && args[len(args)-1] == "missing":
+		fmt.Fprintln(os.Stderr, "No matching secret found")
+		os.Exit(1)
+	default:
+		fmt.Fprintln(os.Stdout, strings.Join(args, " "))
+	}
+	os.Exit(0)
+}
+
+func TestResolveSecret(t *testing.T) {
+	execCommand = GetHelperCommand("TestSecretHelperProcess")
+	defer func() { execCommand = exec.Command }()
+
+	dir, err := ioutil.TempDir("", "ralph-cli-tests-")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "secret")
+	if err := ioutil.WriteFile(secretFile, []byte("secret_from_file\n"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	os.Setenv("RALPH_CLI_TEST_SECRET", "secret_from_env")
+	defer os.Unsetenv("RALPH_CLI_TEST_SECRET")
+
+	var cases = map[string]struct {
+		ref    string
+		want   string
+		errMsg string
+	}{
+		"#0 Plain value": {
+			ref:  "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+			want: "abcdefghijklmnopqrstuwxyz0123456789ABCDE",
+		},
+		"#1 Escaped plain value": {
+			ref:  "plain:env:not_a_reference",
+			want: "env:not_a_reference",
+		},
+		"#2 Environment variable": {
+			ref:  "env:RALPH_CLI_TEST_SECRET",
+			want: "secret_from_env",
+		},
+		"#3 Missing environment variable": {
+			ref:    "env:RALPH_CLI_TEST_DOES_NOT_EXIST",
+			errMsg: "environment variable RALPH_CLI_TEST_DOES_NOT_EXIST is not set",
+		},
+		"#4 File": {
+			ref:  "file:" + secretFile,
+			want: "secret_from_file",
+		},
+		"#5 Missing file": {
+			ref:    "file:" + filepath.Join(dir, "does_not_exist"),
+			errMsg: "error reading secret from file",
+		},
+		"#6 Command": {
+			ref:  "cmd:pass show ralph/idrac",
+			want: "sh -c pass show ralph/idrac",
+		},
+		"#7 Secret Service": {
+			ref:  "secret-service:service ralph attribute api-key",
+			want: "secret-tool lookup service ralph attribute api-key",
+		},
+		"#8 Secret Service with invalid attributes": {
+			ref:    "secret-service:service",
+			errMsg: "should consist of attribute-value pairs",
+		},
+		"#9 Secret Service without such secret": {
+			ref:    "secret-service:service missing",
+			errMsg: "error running secret-tool: exit status 1 (No matching secret found)",
+		},
+		"#10 Kernel keyring": {
+			ref:  "keyring:ralph-api-key",
+			want: "keyctl pipe 123456",
+		},
+	}
+
+	for tn, tc := range cases {
+		got, err := ResolveSecret(tc.ref)
+		switch {
+		case tc.errMsg != "":
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("%s\ndidn't get expected string: %q in err msg: %q", tn, tc.errMsg, err)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("%s\nerr: %s", tn, err)
+			}
+			if got != tc.want {
+				t.Errorf("%s\n got: %q\nwant: %q", tn, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestResolveSecretOnce(t *testing.T) {
+	var mu sync.Mutex
+	var calls int
+	helperCommand := GetHelperCommand("TestSecretHelperProcess")
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return helperCommand(name, args...)
+	}
+	defer func() { execCommand = exec.Command }()
+
+	var wg sync.WaitGroup
+	got := make([]string, 8)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i], _ = resolveSecretOnce("cmd:pass show ralph/once")
+		}(i)
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("command has been run %d times, want 1", calls)
+	}
+	for i, secret := range got {
+		if secret != "sh -c pass show ralph/once" {
+			t.Errorf("#%d\n got: %q\nwant: %q", i, secret, "sh -c pass show ralph/once")
+		}
+	}
+}
+
+func TestGetConfigWithSecrets(t *testing.T) {
+	// Secrets are resolved only when needed (see NewClient and
+	// Config.ForHost), so unresolvable ones don't make GetConfig fail.
+	got, err := GetConfig(filepath.Join(configTestFixturesDir, "config.toml"), "", map[string]string{
+		"RalphAPIKey":            "cmd:exit 1",
+		"ManagementUserPassword": "env:RALPH_CLI_TEST_DOES_NOT_EXIST",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got.RalphAPIKey != "cmd:exit 1" {
+		t.Errorf("\n got: %v\nwant: %v", got.RalphAPIKey, "cmd:exit 1")
+	}
+	if got.ManagementUserPassword != "env:RALPH_CLI_TEST_DOES_NOT_EXIST" {
+		t.Errorf("\n got: %v\nwant: %v", got.ManagementUserPassword, "env:RALPH_CLI_TEST_DOES_NOT_EXIST")
+	}
+}